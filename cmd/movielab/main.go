@@ -1,24 +1,92 @@
 package main
 
 import (
+	"context"
 	"github.com/hobord/routegroup"
 	"github.com/mvrilo/go-redoc"
 	"github.com/rmntim/movielab/internal/config"
+	"github.com/rmntim/movielab/internal/lib/imaging"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/lib/purge"
+	actorCostars "github.com/rmntim/movielab/internal/server/handlers/actors/costars"
 	actorsCreate "github.com/rmntim/movielab/internal/server/handlers/actors/create"
 	actorsDelete "github.com/rmntim/movielab/internal/server/handlers/actors/delete"
+	actorDuplicates "github.com/rmntim/movielab/internal/server/handlers/actors/duplicates"
 	actorsGet "github.com/rmntim/movielab/internal/server/handlers/actors/get"
+	headshotDelete "github.com/rmntim/movielab/internal/server/handlers/actors/headshot/delete"
+	headshotUpdate "github.com/rmntim/movielab/internal/server/handlers/actors/headshot/update"
+	actorMerge "github.com/rmntim/movielab/internal/server/handlers/actors/merge"
+	actorPath "github.com/rmntim/movielab/internal/server/handlers/actors/path"
 	actorsQuery "github.com/rmntim/movielab/internal/server/handlers/actors/query"
+	actorsRestore "github.com/rmntim/movielab/internal/server/handlers/actors/restore"
+	actorTagsCreate "github.com/rmntim/movielab/internal/server/handlers/actors/tags/create"
+	actorTagsDelete "github.com/rmntim/movielab/internal/server/handlers/actors/tags/delete"
+	actorTranslationsDelete "github.com/rmntim/movielab/internal/server/handlers/actors/translations/delete"
+	actorTranslationsQuery "github.com/rmntim/movielab/internal/server/handlers/actors/translations/query"
+	actorTranslationsUpdate "github.com/rmntim/movielab/internal/server/handlers/actors/translations/update"
 	actorsUpdate "github.com/rmntim/movielab/internal/server/handlers/actors/update"
+	auditQuery "github.com/rmntim/movielab/internal/server/handlers/audit/query"
 	"github.com/rmntim/movielab/internal/server/handlers/auth"
+	awardCategoriesCreate "github.com/rmntim/movielab/internal/server/handlers/awards/categories/create"
+	awardCeremoniesCreate "github.com/rmntim/movielab/internal/server/handlers/awards/ceremonies/create"
+	awardsCreate "github.com/rmntim/movielab/internal/server/handlers/awards/create"
+	awardsDelete "github.com/rmntim/movielab/internal/server/handlers/awards/delete"
+	awardsQuery "github.com/rmntim/movielab/internal/server/handlers/awards/query"
+	collectionsCreate "github.com/rmntim/movielab/internal/server/handlers/collections/create"
+	collectionsDelete "github.com/rmntim/movielab/internal/server/handlers/collections/delete"
+	collectionsGet "github.com/rmntim/movielab/internal/server/handlers/collections/get"
+	collectionMoviesCreate "github.com/rmntim/movielab/internal/server/handlers/collections/movies/create"
+	collectionMoviesDelete "github.com/rmntim/movielab/internal/server/handlers/collections/movies/delete"
+	collectionMoviesUpdate "github.com/rmntim/movielab/internal/server/handlers/collections/movies/update"
+	collectionsQuery "github.com/rmntim/movielab/internal/server/handlers/collections/query"
+	collectionsUpdate "github.com/rmntim/movielab/internal/server/handlers/collections/update"
+	historyCreate "github.com/rmntim/movielab/internal/server/handlers/me/history/create"
+	historyDelete "github.com/rmntim/movielab/internal/server/handlers/me/history/delete"
+	historyQuery "github.com/rmntim/movielab/internal/server/handlers/me/history/query"
+	"github.com/rmntim/movielab/internal/server/handlers/me/recommendations"
+	watchlistCreate "github.com/rmntim/movielab/internal/server/handlers/me/watchlist/create"
+	watchlistDelete "github.com/rmntim/movielab/internal/server/handlers/me/watchlist/delete"
+	watchlistQuery "github.com/rmntim/movielab/internal/server/handlers/me/watchlist/query"
 	moviesCreate "github.com/rmntim/movielab/internal/server/handlers/movies/create"
+	crewCreate "github.com/rmntim/movielab/internal/server/handlers/movies/crew/create"
+	crewDelete "github.com/rmntim/movielab/internal/server/handlers/movies/crew/delete"
 	moviesDelete "github.com/rmntim/movielab/internal/server/handlers/movies/delete"
 	moviesGet "github.com/rmntim/movielab/internal/server/handlers/movies/get"
+	posterDelete "github.com/rmntim/movielab/internal/server/handlers/movies/poster/delete"
+	posterUpdate "github.com/rmntim/movielab/internal/server/handlers/movies/poster/update"
 	moviesQuery "github.com/rmntim/movielab/internal/server/handlers/movies/query"
+	moviesRestore "github.com/rmntim/movielab/internal/server/handlers/movies/restore"
+	movieRevisionsQuery "github.com/rmntim/movielab/internal/server/handlers/movies/revisions/query"
+	movieRevisionsRestore "github.com/rmntim/movielab/internal/server/handlers/movies/revisions/restore"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/search"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/similar"
+	movieTagsCreate "github.com/rmntim/movielab/internal/server/handlers/movies/tags/create"
+	movieTagsDelete "github.com/rmntim/movielab/internal/server/handlers/movies/tags/delete"
+	movieTranslationsDelete "github.com/rmntim/movielab/internal/server/handlers/movies/translations/delete"
+	movieTranslationsQuery "github.com/rmntim/movielab/internal/server/handlers/movies/translations/query"
+	movieTranslationsUpdate "github.com/rmntim/movielab/internal/server/handlers/movies/translations/update"
 	moviesUpdate "github.com/rmntim/movielab/internal/server/handlers/movies/update"
+	nominationsCreate "github.com/rmntim/movielab/internal/server/handlers/nominations/create"
+	nominationsDelete "github.com/rmntim/movielab/internal/server/handlers/nominations/delete"
+	nominationsQuery "github.com/rmntim/movielab/internal/server/handlers/nominations/query"
+	peopleGet "github.com/rmntim/movielab/internal/server/handlers/people/get"
+	peopleQuery "github.com/rmntim/movielab/internal/server/handlers/people/query"
+	reviewsCreate "github.com/rmntim/movielab/internal/server/handlers/reviews/create"
+	reviewsDelete "github.com/rmntim/movielab/internal/server/handlers/reviews/delete"
+	reviewsQuery "github.com/rmntim/movielab/internal/server/handlers/reviews/query"
+	reviewsUpdate "github.com/rmntim/movielab/internal/server/handlers/reviews/update"
+	statsActors "github.com/rmntim/movielab/internal/server/handlers/stats/actors"
+	statsAges "github.com/rmntim/movielab/internal/server/handlers/stats/ages"
+	statsCast "github.com/rmntim/movielab/internal/server/handlers/stats/cast"
+	statsRatings "github.com/rmntim/movielab/internal/server/handlers/stats/ratings"
+	statsYears "github.com/rmntim/movielab/internal/server/handlers/stats/years"
+	tagsQuery "github.com/rmntim/movielab/internal/server/handlers/tags/query"
+	"github.com/rmntim/movielab/internal/server/middleware/cachecontrol"
 	jwtMw "github.com/rmntim/movielab/internal/server/middleware/jwt"
 	loggerMw "github.com/rmntim/movielab/internal/server/middleware/logger"
+	"github.com/rmntim/movielab/internal/server/middleware/problem"
+	"github.com/rmntim/movielab/internal/server/middleware/requestid"
+	"github.com/rmntim/movielab/internal/storage/blob/local"
 	"github.com/rmntim/movielab/internal/storage/postgres"
 	"log/slog"
 	"net/http"
@@ -38,13 +106,23 @@ func main() {
 	log.Info("Starting server", slog.String("env", cfg.Env))
 	log.Debug("Debug messages are enabled")
 
-	storage, err := postgres.New(cfg.DBUrl)
+	storage, err := postgres.New(cfg.DBUrl, postgres.WithStatsCache(cfg.StatsConfig.CacheTTL))
 	if err != nil {
 		log.Error("Failed to init storage", sl.Err(err))
 		os.Exit(1)
 	}
 
-	handler := setupHandler(cfg, log, storage)
+	// Deleted movies and actors can be restored until retention period passes
+	go purge.Run(context.Background(), log, storage, cfg.PurgeConfig.Interval, cfg.PurgeConfig.Retention)
+
+	blobStore, err := local.New(cfg.ImagesConfig.Dir, cfg.ImagesConfig.BaseURL)
+	if err != nil {
+		log.Error("Failed to init image storage", sl.Err(err))
+		os.Exit(1)
+	}
+	uploader := imaging.NewUploader(blobStore, cfg.ThumbnailWidths)
+
+	handler := setupHandler(cfg, log, storage, uploader)
 
 	log.Info("Starting server", slog.String("address", cfg.Address))
 	srv := &http.Server{
@@ -62,7 +140,7 @@ func main() {
 	log.Info("Server stopped")
 }
 
-func setupHandler(cfg *config.Config, log *slog.Logger, storage *postgres.Storage) http.Handler {
+func setupHandler(cfg *config.Config, log *slog.Logger, storage *postgres.Storage, uploader *imaging.Uploader) http.Handler {
 	mux := http.NewServeMux()
 	root := routegroup.NewGroup(routegroup.WithMux(mux))
 
@@ -72,24 +150,119 @@ func setupHandler(cfg *config.Config, log *slog.Logger, storage *postgres.Storag
 	apiGroup.Use(jwtMw.New(cfg.JwtSecret))
 
 	movieGroup := apiGroup.SubGroup("/movies")
-	movieGroup.HandleFunc("GET /", moviesQuery.New(log, storage))
+	movieGroup.Handle("GET /", cachecontrol.New(cfg.CacheConfig.MovieList)(moviesQuery.New(log, storage)))
 	movieGroup.HandleFunc("POST /", moviesCreate.New(log, storage))
 
-	movieGroup.HandleFunc("GET /{id}", moviesGet.New(log, storage))
-	movieGroup.HandleFunc("DELETE /{id}", moviesDelete.New(log, storage))
-	movieGroup.HandleFunc("PUT /{id}", moviesUpdate.New(log, storage))
-	movieGroup.HandleFunc("PATCH /{id}", moviesUpdate.New(log, storage))
+	movieGroup.Handle("GET /{id}", cachecontrol.New(cfg.CacheConfig.Movie)(moviesGet.New(log, storage)))
+	movieGroup.HandleFunc("DELETE /{id}", moviesDelete.New(log, storage, cfg.RequireIfMatch))
+	movieGroup.HandleFunc("PUT /{id}", moviesUpdate.New(log, storage, cfg.RequireIfMatch))
+	movieGroup.HandleFunc("PATCH /{id}", moviesUpdate.New(log, storage, cfg.RequireIfMatch))
+	movieGroup.HandleFunc("POST /{id}/restore", moviesRestore.New(log, storage))
+	movieGroup.HandleFunc("GET /{id}/revisions", movieRevisionsQuery.New(log, storage))
+	movieGroup.HandleFunc("POST /{id}/revisions/{rev}/restore", movieRevisionsRestore.New(log, storage))
+
+	movieGroup.HandleFunc("PUT /{id}/poster", posterUpdate.New(log, storage, uploader, cfg.MaxSize))
+	movieGroup.HandleFunc("DELETE /{id}/poster", posterDelete.New(log, storage, uploader))
+
+	movieGroup.HandleFunc("GET /{id}/translations", movieTranslationsQuery.New(log, storage))
+	movieGroup.HandleFunc("PUT /{id}/translations/{lang}", movieTranslationsUpdate.New(log, storage))
+	movieGroup.HandleFunc("DELETE /{id}/translations/{lang}", movieTranslationsDelete.New(log, storage))
+
+	movieGroup.HandleFunc("POST /{id}/tags", movieTagsCreate.New(log, storage))
+	movieGroup.HandleFunc("DELETE /{id}/tags/{tag}", movieTagsDelete.New(log, storage))
+
+	movieGroup.HandleFunc("POST /{id}/crew", crewCreate.New(log, storage))
+	movieGroup.HandleFunc("DELETE /{id}/crew/{person_id}/{role}", crewDelete.New(log, storage))
+
+	movieGroup.HandleFunc("GET /{id}/reviews", reviewsQuery.New(log, storage))
+	movieGroup.HandleFunc("POST /{id}/reviews", reviewsCreate.New(log, storage))
+	movieGroup.HandleFunc("PUT /{id}/reviews/{review_id}", reviewsUpdate.New(log, storage))
+	movieGroup.HandleFunc("PATCH /{id}/reviews/{review_id}", reviewsUpdate.New(log, storage))
+	movieGroup.HandleFunc("DELETE /{id}/reviews/{review_id}", reviewsDelete.New(log, storage))
+
+	movieGroup.HandleFunc("GET /{id}/similar", similar.New(log, storage))
 
 	movieGroup.HandleFunc("GET /search", search.New(log, storage))
 
 	actorGroup := apiGroup.SubGroup("/actors")
-	actorGroup.HandleFunc("GET /", actorsQuery.New(log, storage))
+	actorGroup.Handle("GET /", cachecontrol.New(cfg.CacheConfig.ActorList)(actorsQuery.New(log, storage)))
 	actorGroup.HandleFunc("POST /", actorsCreate.New(log, storage))
+	actorGroup.HandleFunc("GET /duplicates", actorDuplicates.New(log, storage))
+
+	actorGroup.Handle("GET /{id}", cachecontrol.New(cfg.CacheConfig.Actor)(actorsGet.New(log, storage)))
+	actorGroup.HandleFunc("DELETE /{id}", actorsDelete.New(log, storage, cfg.RequireIfMatch))
+	actorGroup.HandleFunc("PUT /{id}", actorsUpdate.New(log, storage, cfg.RequireIfMatch))
+	actorGroup.HandleFunc("PATCH /{id}", actorsUpdate.New(log, storage, cfg.RequireIfMatch))
+	actorGroup.HandleFunc("POST /{id}/restore", actorsRestore.New(log, storage))
+
+	actorGroup.HandleFunc("GET /{id}/translations", actorTranslationsQuery.New(log, storage))
+	actorGroup.HandleFunc("PUT /{id}/translations/{lang}", actorTranslationsUpdate.New(log, storage))
+	actorGroup.HandleFunc("DELETE /{id}/translations/{lang}", actorTranslationsDelete.New(log, storage))
+
+	actorGroup.HandleFunc("POST /{id}/tags", actorTagsCreate.New(log, storage))
+	actorGroup.HandleFunc("DELETE /{id}/tags/{tag}", actorTagsDelete.New(log, storage))
+
+	actorGroup.HandleFunc("PUT /{id}/headshot", headshotUpdate.New(log, storage, uploader, cfg.MaxSize))
+	actorGroup.HandleFunc("DELETE /{id}/headshot", headshotDelete.New(log, storage, uploader))
+
+	actorGroup.HandleFunc("POST /{id}/merge", actorMerge.New(log, storage))
+
+	actorGroup.HandleFunc("GET /{id}/costars", actorCostars.New(log, storage))
+	actorGroup.HandleFunc("GET /{id}/path/{to_id}", actorPath.New(log, storage))
+
+	peopleGroup := apiGroup.SubGroup("/people")
+	peopleGroup.HandleFunc("GET /", peopleQuery.New(log, storage))
+	peopleGroup.HandleFunc("GET /{id}", peopleGet.New(log, storage))
+
+	collectionGroup := apiGroup.SubGroup("/collections")
+	collectionGroup.HandleFunc("GET /", collectionsQuery.New(log, storage))
+	collectionGroup.HandleFunc("POST /", collectionsCreate.New(log, storage))
+
+	collectionGroup.HandleFunc("GET /{id}", collectionsGet.New(log, storage))
+	collectionGroup.HandleFunc("DELETE /{id}", collectionsDelete.New(log, storage))
+	collectionGroup.HandleFunc("PUT /{id}", collectionsUpdate.New(log, storage))
+	collectionGroup.HandleFunc("PATCH /{id}", collectionsUpdate.New(log, storage))
+
+	collectionGroup.HandleFunc("POST /{id}/movies", collectionMoviesCreate.New(log, storage))
+	collectionGroup.HandleFunc("PUT /{id}/movies", collectionMoviesUpdate.New(log, storage))
+	collectionGroup.HandleFunc("DELETE /{id}/movies/{movie_id}", collectionMoviesDelete.New(log, storage))
+
+	meGroup := apiGroup.SubGroup("/me")
+	meGroup.HandleFunc("GET /watchlist", watchlistQuery.New(log, storage))
+	meGroup.HandleFunc("POST /watchlist", watchlistCreate.New(log, storage))
+	meGroup.HandleFunc("DELETE /watchlist/{movie_id}", watchlistDelete.New(log, storage))
+
+	meGroup.HandleFunc("GET /history", historyQuery.New(log, storage))
+	meGroup.HandleFunc("POST /history", historyCreate.New(log, storage))
+	meGroup.HandleFunc("DELETE /history/{id}", historyDelete.New(log, storage))
+
+	meGroup.HandleFunc("GET /recommendations", recommendations.New(log, storage))
+
+	apiGroup.HandleFunc("GET /tags", tagsQuery.New(log, storage))
+
+	awardGroup := apiGroup.SubGroup("/awards")
+	awardGroup.HandleFunc("GET /", awardsQuery.New(log, storage))
+	awardGroup.HandleFunc("POST /", awardsCreate.New(log, storage))
+	awardGroup.HandleFunc("DELETE /{id}", awardsDelete.New(log, storage))
+	awardGroup.HandleFunc("POST /{id}/categories", awardCategoriesCreate.New(log, storage))
+	awardGroup.HandleFunc("POST /{id}/ceremonies", awardCeremoniesCreate.New(log, storage))
+
+	nominationGroup := apiGroup.SubGroup("/nominations")
+	nominationGroup.HandleFunc("GET /", nominationsQuery.New(log, storage))
+	nominationGroup.HandleFunc("POST /", nominationsCreate.New(log, storage))
+	nominationGroup.HandleFunc("DELETE /{id}", nominationsDelete.New(log, storage))
+
+	apiGroup.HandleFunc("GET /audit", auditQuery.New(log, storage))
+
+	statsGroup := apiGroup.SubGroup("/stats")
+	statsGroup.HandleFunc("GET /movies-per-year", statsYears.New(log, storage))
+	statsGroup.HandleFunc("GET /ratings", statsRatings.New(log, storage))
+	statsGroup.HandleFunc("GET /prolific-actors", statsActors.New(log, storage))
+	statsGroup.HandleFunc("GET /cast-size", statsCast.New(log, storage))
+	statsGroup.HandleFunc("GET /actor-ages", statsAges.New(log, storage))
 
-	actorGroup.HandleFunc("GET /{id}", actorsGet.New(log, storage))
-	actorGroup.HandleFunc("DELETE /{id}", actorsDelete.New(log, storage))
-	actorGroup.HandleFunc("PUT /{id}", actorsUpdate.New(log, storage))
-	actorGroup.HandleFunc("PATCH /{id}", actorsUpdate.New(log, storage))
+	// Uploaded images are public, so they are served outside of authenticated api group
+	root.Handle("GET /images/", http.StripPrefix("/images/", http.FileServer(http.Dir(cfg.ImagesConfig.Dir))))
 
 	doc := redoc.Redoc{
 		SpecFile: "./api/openapi.yaml",
@@ -101,8 +274,12 @@ func setupHandler(cfg *config.Config, log *slog.Logger, storage *postgres.Storag
 	root.Handle("/docs", docHandler)
 	root.Handle("/openapi.yaml", docHandler)
 
+	// Error responses of all handlers, including ones of mux itself, are turned into problem details
+	handler := problem.New()(root)
 	// Have to put logger last, cause routegroup package is foolish with it
-	handler := loggerMw.New(log)(root)
+	handler = loggerMw.New(log)(handler)
+	// Request id is assigned before logging, so it shows up in request log and audit log alike
+	handler = requestid.New()(handler)
 	return handler
 }
 
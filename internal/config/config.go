@@ -12,6 +12,10 @@ type Config struct {
 	Env              string `yaml:"env" env-required:"true"`
 	DBUrl            string `env:"DATABASE_URL" env-required:"true"`
 	HTTPServerConfig `yaml:"http_server"`
+	ImagesConfig     `yaml:"images"`
+	StatsConfig      `yaml:"stats"`
+	PurgeConfig      `yaml:"purge"`
+	CacheConfig      `yaml:"cache"`
 }
 
 type HTTPServerConfig struct {
@@ -19,6 +23,40 @@ type HTTPServerConfig struct {
 	Timeout     time.Duration `yaml:"timeout" env-default:"5s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 	JwtSecret   string        `yaml:"jwt_secret" env-required:"true"`
+	// RequireIfMatch rejects updates and deletes of movies and actors without If-Match header
+	RequireIfMatch bool `yaml:"require_if_match" env-default:"false"`
+}
+
+// ImagesConfig configures storage of uploaded images
+type ImagesConfig struct {
+	Dir string `yaml:"dir" env-default:"./images"`
+	// BaseURL is prepended to image keys in responses, images from Dir are served under /images/
+	BaseURL         string `yaml:"base_url" env-default:"/images"`
+	MaxSize         int64  `yaml:"max_size" env-default:"10485760"`
+	ThumbnailWidths []int  `yaml:"thumbnail_widths" env-default:"100,300,600"`
+}
+
+// StatsConfig configures /api/stats endpoints
+type StatsConfig struct {
+	// CacheTTL is how long statistics are cached, zero disables caching
+	CacheTTL time.Duration `yaml:"cache_ttl" env-default:"0s"`
+}
+
+// PurgeConfig configures permanent removal of deleted movies and actors
+type PurgeConfig struct {
+	// Retention is how long deleted movies and actors can be restored
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+	// Interval is time between purges, zero disables purging
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+}
+
+// CacheConfig sets Cache-Control policy of read endpoints, empty policy leaves the header out.
+// Responses depend on requesting user, so they shouldn't be stored by shared caches.
+type CacheConfig struct {
+	Movie     string `yaml:"movie" env-default:"private, no-cache"`
+	MovieList string `yaml:"movie_list" env-default:"private, no-cache"`
+	Actor     string `yaml:"actor" env-default:"private, no-cache"`
+	ActorList string `yaml:"actor_list" env-default:"private, no-cache"`
 }
 
 func MustLoad() *Config {
@@ -5,11 +5,72 @@ import "time"
 type Actor struct {
 	ID int `json:"id"`
 	NewActor
+	// Age is current age, or age at death for deceased actors
+	Age      int     `json:"age"`
 	MovieIDs []int32 `json:"movie_ids"`
+	Headshot *Image  `json:"headshot,omitempty"`
+	// Movies are movies of MovieIDs, only set when requested with `include=movies`, then they are rendered even if empty
+	Movies *[]Movie `json:"movies,omitempty"`
+	// Tags are managed with /tags endpoints of the actor
+	Tags []string `json:"tags"`
+	// DeletedAt is set for deleted actors, which are only listed to admins until purged
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version is incremented by every change of the actor, it is sent as ETag
+	Version int `json:"-"`
+	// UpdatedAt is time of the last change of the actor or its related records, it is sent as Last-Modified
+	UpdatedAt time.Time `json:"-"`
+}
+
+// LastModified returns time of the last change of the actor or any of its embedded movies
+func (a *Actor) LastModified() time.Time {
+	modified := a.UpdatedAt
+	if a.Movies != nil {
+		for _, embedded := range *a.Movies {
+			if embedded.UpdatedAt.After(modified) {
+				modified = embedded.UpdatedAt
+			}
+		}
+	}
+	return modified
 }
 
 type NewActor struct {
-	Name      string    `json:"name"`
-	Sex       string    `json:"sex"`
-	BirthDate time.Time `json:"birthdate"`
+	Name string `json:"name" validate:"required,max=255"`
+	Sex  string `json:"sex" validate:"required,oneof=male female"`
+	// Gender is free-form, e.g. female, male or non-binary, it doesn't replace sex
+	Gender     string     `json:"gender,omitempty" validate:"max=50"`
+	BirthDate  time.Time  `json:"birthdate" validate:"notfuture"`
+	DeathDate  *time.Time `json:"deathdate,omitempty" validate:"omitempty,gtfield=BirthDate,notfuture"`
+	Birthplace string     `json:"birthplace,omitempty" validate:"max=255"`
+	Biography  string     `json:"biography,omitempty" validate:"max=10000"`
+	// Aliases are alternate and stage names, they are matched by name searches
+	Aliases []string `json:"aliases,omitempty" validate:"dive,required,max=255"`
+}
+
+// ActorFilter narrows down actor list, zero valued fields are not applied
+type ActorFilter struct {
+	// Name matches original and translated names and aliases
+	Name string
+	// Tags match actors having all of the tags
+	Tags []string
+	AwardFilter
+	// IncludeDeleted lists deleted actors along with the rest
+	IncludeDeleted bool
+}
+
+// AgeAt returns full years actor lived by given time, deceased actors stop aging at death date
+func (a *NewActor) AgeAt(now time.Time) int {
+	if a.DeathDate != nil && a.DeathDate.Before(now) {
+		now = *a.DeathDate
+	}
+
+	birth := a.BirthDate.UTC()
+	now = now.UTC()
+
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || now.Month() == birth.Month() && now.Day() < birth.Day() {
+		age--
+	}
+
+	return max(age, 0)
 }
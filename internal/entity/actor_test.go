@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestActorAgeAt(t *testing.T) {
+	birth := time.Date(1950, time.June, 15, 0, 0, 0, 0, time.UTC)
+	death := time.Date(2000, time.June, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		deathDate *time.Time
+		now       time.Time
+		age       int
+	}{
+		{
+			name: "Before birthday",
+			now:  time.Date(2020, time.June, 14, 0, 0, 0, 0, time.UTC),
+			age:  69,
+		},
+		{
+			name: "On birthday",
+			now:  time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC),
+			age:  70,
+		},
+		{
+			name:      "Deceased",
+			deathDate: &death,
+			now:       time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC),
+			age:       49,
+		},
+		{
+			name:      "Death date in future",
+			deathDate: &death,
+			now:       time.Date(1990, time.June, 15, 0, 0, 0, 0, time.UTC),
+			age:       40,
+		},
+		{
+			name: "Not born yet",
+			now:  time.Date(1940, time.June, 15, 0, 0, 0, 0, time.UTC),
+			age:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actor := entity.NewActor{BirthDate: birth, DeathDate: tt.deathDate}
+			require.Equal(t, tt.age, actor.AgeAt(tt.now))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited actions
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditMerge   = "merge"
+	// AuditRevert brings back fields of earlier revision
+	AuditRevert = "revert"
+)
+
+// Audited entity types
+const (
+	AuditMovie = "movie"
+	AuditActor = "actor"
+)
+
+// AuditInfo tells who made a change, it is recorded in audit log together with the change
+type AuditInfo struct {
+	Username  string
+	RequestID string
+}
+
+// FieldChange holds JSON values of a field before and after the change, missing value is null
+type FieldChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditEntry is a record of a change made to movie or actor, Changes only list fields that were changed
+type AuditEntry struct {
+	ID         int64                  `json:"id"`
+	Username   string                 `json:"username"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int                    `json:"entity_id"`
+	Changes    map[string]FieldChange `json:"changes"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditFilter narrows down audit log, zero valued fields are not applied
+type AuditFilter struct {
+	Username   string
+	Action     string `query:"action" validate:"omitempty,oneof=create update delete restore merge revert"`
+	EntityType string `query:"entity_type" validate:"omitempty,oneof=movie actor"`
+	EntityID   int    `query:"entity_id" validate:"min=0"`
+	// From and To limit time of change, From is inclusive and To is exclusive
+	From *time.Time
+	To   *time.Time
+}
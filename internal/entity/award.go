@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// Award is a recurring event giving awards in categories, e.g. Academy Awards
+type Award struct {
+	ID int `json:"id"`
+	NewAward
+	Categories []AwardCategory `json:"categories"`
+	Ceremonies []Ceremony      `json:"ceremonies"`
+}
+
+type NewAward struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// AwardCategory is a category of award, e.g. Best Picture
+type AwardCategory struct {
+	ID int `json:"id"`
+	NewAwardCategory
+}
+
+type NewAwardCategory struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// Ceremony is a yearly event of award where nominees and winners are announced
+type Ceremony struct {
+	ID int `json:"id"`
+	NewCeremony
+}
+
+type NewCeremony struct {
+	Year   int        `json:"year" validate:"required,min=1800,max=3000"`
+	HeldOn *time.Time `json:"held_on,omitempty"`
+}
+
+// Nomination of a movie, an actor, or an actor for their role in a movie
+type Nomination struct {
+	ID       int           `json:"id"`
+	Award    string        `json:"award"`
+	Year     int           `json:"year"`
+	Category string        `json:"category"`
+	Movie    *MovieSummary `json:"movie,omitempty"`
+	Actor    *ActorSummary `json:"actor,omitempty"`
+	Won      bool          `json:"won"`
+}
+
+type NewNomination struct {
+	CeremonyID int `json:"ceremony_id" validate:"required"`
+	// CategoryID must belong to the same award as ceremony
+	CategoryID int  `json:"category_id" validate:"required"`
+	MovieID    int  `json:"movie_id" validate:"required_without=ActorID"`
+	ActorID    int  `json:"actor_id" validate:"required_without=MovieID"`
+	Won        bool `json:"won"`
+}
+
+// NominationFilter narrows down nomination list, zero valued fields are not applied
+type NominationFilter struct {
+	AwardID    int
+	CeremonyID int
+	CategoryID int
+	MovieID    int
+	ActorID    int
+	// Won only returns winners when true
+	Won bool
+}
+
+// AwardFilter narrows down movies or actors by their nominations, zero valued fields are not applied
+type AwardFilter struct {
+	// AwardCategoryID matches nominees in the category, or winners if AwardWon is set
+	AwardCategoryID int `query:"award_category" validate:"min=0"`
+	// AwardWon alone matches winners of any award
+	AwardWon       bool
+	MinNominations int `query:"min_nominations" validate:"min=0"`
+	MinWins        int `query:"min_wins" validate:"min=0"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+// Collection represents an ordered, curated list of movies
+type Collection struct {
+	ID    int    `json:"id"`
+	Owner string `json:"owner"`
+	NewCollection
+	// MovieIDs are ordered by position in collection
+	MovieIDs []int32 `json:"movie_ids"`
+	// Movies are embedded only when a single collection is requested
+	Movies []Movie `json:"movies,omitempty"`
+}
+
+type NewCollection struct {
+	Title       string `json:"title" validate:"required,max=150"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+	Public      bool   `json:"public"`
+}
+
+// VisibleTo tells if collection can be read by user with given name and role
+func (c *Collection) VisibleTo(username, role string) bool {
+	return c.Public || c.EditableBy(username, role)
+}
+
+// EditableBy tells if collection can be modified by user with given name and role
+func (c *Collection) EditableBy(username, role string) bool {
+	return c.Owner == username || role == "admin"
+}
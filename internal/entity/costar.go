@@ -0,0 +1,28 @@
+package entity
+
+// ActorSummary is a short reference to an actor
+type ActorSummary struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+// MovieSummary is a short reference to a movie
+type MovieSummary struct {
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+}
+
+// Costar is an actor who played in the same movies as another actor
+type Costar struct {
+	ActorSummary
+	SharedMovies int     `json:"shared_movies"`
+	MovieIDs     []int32 `json:"movie_ids"`
+}
+
+// ActorPath is a shortest chain of actors linked by movies they played in together,
+// Movies[i] links Actors[i] and Actors[i+1]
+type ActorPath struct {
+	Degrees int            `json:"degrees"`
+	Actors  []ActorSummary `json:"actors"`
+	Movies  []MovieSummary `json:"movies"`
+}
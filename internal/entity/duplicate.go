@@ -0,0 +1,15 @@
+package entity
+
+// DuplicateCandidate is a pair of actors that may be the same person
+type DuplicateCandidate struct {
+	Actor     ActorSummary `json:"actor"`
+	Duplicate ActorSummary `json:"duplicate"`
+	// Similarity of names from 0 to 1
+	Similarity    float64 `json:"similarity"`
+	SameBirthDate bool    `json:"same_birthdate"`
+}
+
+// ActorMerge describes duplicate actor merged into another one
+type ActorMerge struct {
+	DuplicateID int `json:"duplicate_id" validate:"required"`
+}
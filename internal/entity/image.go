@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Image represents uploaded picture, such as movie poster or actor headshot
+type Image struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// Thumbnails maps thumbnail width to its URL
+	Thumbnails map[int]string `json:"thumbnails"`
+	// Prefix is blob key prefix of this version of the image, it is stored but not sent to clients
+	Prefix string `json:"-"`
+}
+
+// storedImage is Image as stored in database, along with its prefix
+type storedImage struct {
+	Image
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Scan implements sql.Scanner, images are stored as JSONB
+func (i *Image) Scan(src any) error {
+	var stored storedImage
+	switch v := src.(type) {
+	case []byte:
+		if err := json.Unmarshal(v, &stored); err != nil {
+			return err
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &stored); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported image source type")
+	}
+
+	*i = stored.Image
+	i.Prefix = stored.Prefix
+	return nil
+}
+
+// Value implements driver.Valuer
+func (i Image) Value() (driver.Value, error) {
+	return json.Marshal(storedImage{Image: i, Prefix: i.Prefix})
+}
@@ -0,0 +1,27 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestImageStorage(t *testing.T) {
+	t.Parallel()
+
+	image := entity.Image{URL: "/images/movies/1/poster/v1/original", Width: 10, Height: 15, Thumbnails: map[int]string{},
+		Prefix: "movies/1/poster/v1"}
+
+	stored, err := image.Value()
+	require.NoError(t, err)
+
+	var scanned entity.Image
+	require.NoError(t, scanned.Scan(stored))
+	require.Equal(t, image, scanned)
+
+	// Prefix is internal, it isn't sent to clients
+	sent, err := json.Marshal(image)
+	require.NoError(t, err)
+	require.NotContains(t, string(sent), "prefix")
+}
@@ -8,12 +8,79 @@ import (
 type Movie struct {
 	ID int `json:"id"`
 	NewMovie
+	Crew      []CrewMember `json:"crew,omitempty"`
+	UserScore *UserScore   `json:"user_score,omitempty"`
+	Poster    *Image       `json:"poster,omitempty"`
+	// Actors are actors of ActorIDs, only set when requested with `include=actors`, then they are rendered even if empty
+	Actors *[]Actor `json:"actors,omitempty"`
+	// InWatchlist tells if movie is in watchlist of the requesting user, only set by read endpoints
+	InWatchlist *bool `json:"in_watchlist,omitempty"`
+	// Tags are managed with /tags endpoints of the movie
+	Tags []string `json:"tags"`
+	// DeletedAt is set for deleted movies, which are only listed to admins until purged
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version is incremented by every change of the movie, it is sent as ETag
+	Version int `json:"-"`
+	// UpdatedAt is time of the last change of the movie or its related records, it is sent as Last-Modified
+	UpdatedAt time.Time `json:"-"`
+}
+
+// LastModified returns time of the last change of the movie or any of its embedded actors
+func (m *Movie) LastModified() time.Time {
+	modified := m.UpdatedAt
+	if m.Actors != nil {
+		for _, embedded := range *m.Actors {
+			if embedded.UpdatedAt.After(modified) {
+				modified = embedded.UpdatedAt
+			}
+		}
+	}
+	return modified
 }
 
 type NewMovie struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description,omitempty"`
+	Title       string    `json:"title" validate:"required,max=150"`
+	Description string    `json:"description,omitempty" validate:"max=1000"`
 	ReleaseDate time.Time `json:"release_date"`
-	Rating      int       `json:"rating"`
-	ActorIDs    []int32   `json:"actor_ids"`
+	Rating      int       `json:"rating" validate:"min=0,max=10"`
+	// ActorIDs must belong to existing actors, which is checked by handlers
+	ActorIDs []int32 `json:"actor_ids" validate:"unique,dive,gt=0"`
+	// Runtime is movie length in minutes
+	Runtime int `json:"runtime,omitempty" validate:"min=0"`
+	// Countries are ISO 3166-1 alpha-2 codes of production countries
+	Countries []string `json:"countries,omitempty" validate:"dive,iso3166_1_alpha2"`
+	// OriginalLanguage is ISO 639-1 code
+	OriginalLanguage string `json:"original_language,omitempty" validate:"omitempty,len=2,alpha,lowercase"`
+	// Certifications map ISO 3166-1 alpha-2 country code to age certification issued there, e.g. PG-13 in US
+	Certifications map[string]string `json:"certifications,omitempty" validate:"dive,keys,iso3166_1_alpha2,endkeys,required,max=10"`
+	Budget         *Money            `json:"budget,omitempty"`
+	BoxOffice      *Money            `json:"box_office,omitempty"`
+}
+
+// Money is an amount in whole units of ISO 4217 currency
+type Money struct {
+	Amount   int64  `json:"amount" validate:"min=0"`
+	Currency string `json:"currency" validate:"required,iso4217"`
+}
+
+// MovieFilter narrows down movie list, zero valued fields are not applied
+type MovieFilter struct {
+	MinRuntime int    `query:"runtime_min" validate:"min=0"`
+	MaxRuntime int    `query:"runtime_max" validate:"min=0"`
+	Country    string `query:"country" validate:"omitempty,iso3166_1_alpha2"`
+	Language   string `query:"language" validate:"omitempty,len=2,alpha,lowercase"`
+	// CertificationCountry alone matches movies certified in the country, with any certification
+	CertificationCountry string `query:"certification" validate:"required_with=Certification,omitempty,iso3166_1_alpha2"`
+	Certification        string `query:"certification" validate:"max=10"`
+	// Currency restricts budget and box office bounds to amounts in given currency
+	Currency     string `query:"currency" validate:"omitempty,iso4217"`
+	MinBudget    int64  `query:"budget_min" validate:"min=0"`
+	MaxBudget    int64  `query:"budget_max" validate:"min=0"`
+	MinBoxOffice int64  `query:"box_office_min" validate:"min=0"`
+	MaxBoxOffice int64  `query:"box_office_max" validate:"min=0"`
+	// Tags match movies having all of the tags
+	Tags []string
+	AwardFilter
+	// IncludeDeleted lists deleted movies along with the rest
+	IncludeDeleted bool
 }
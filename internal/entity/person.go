@@ -0,0 +1,32 @@
+package entity
+
+// Crew roles a person can be credited with on a movie
+const (
+	CrewRoleDirector        = "director"
+	CrewRoleWriter          = "writer"
+	CrewRoleProducer        = "producer"
+	CrewRoleCinematographer = "cinematographer"
+	CrewRoleComposer        = "composer"
+)
+
+// CrewMember represents a non-acting credit of a person on a movie
+type CrewMember struct {
+	PersonID int32  `json:"person_id" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=director writer producer cinematographer composer"`
+}
+
+// Credit represents a non-acting credit from the person's point of view
+type Credit struct {
+	MovieID int32  `json:"movie_id"`
+	Role    string `json:"role"`
+}
+
+// Person represents anybody who worked on a movie. People share the actors table,
+// so the same record can hold both acting and crew credits.
+type Person struct {
+	ID int `json:"id"`
+	NewActor
+	Age      int      `json:"age"`
+	MovieIDs []int32  `json:"movie_ids"`
+	Credits  []Credit `json:"credits"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+// Recommendation reason kinds
+const (
+	ReasonSharedCast = "shared_cast"
+	ReasonReleaseEra = "release_era"
+	ReasonRating     = "rating"
+)
+
+// RecommendationReason explains how much a single signal contributed to recommendation score
+type RecommendationReason struct {
+	Kind        string  `json:"kind"`
+	Score       float64 `json:"score"`
+	Description string  `json:"description"`
+}
+
+// Recommendation is a movie scored by similarity to a movie or to movies user is interested in
+type Recommendation struct {
+	Movie   Movie                  `json:"movie"`
+	Score   float64                `json:"score"`
+	Reasons []RecommendationReason `json:"reasons"`
+}
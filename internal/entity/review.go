@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Review represents user's rating and optional text review of a movie
+type Review struct {
+	ID       int    `json:"id"`
+	MovieID  int    `json:"movie_id"`
+	Username string `json:"username"`
+	NewReview
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NewReview struct {
+	Rating int    `json:"rating" validate:"min=0,max=10"`
+	Text   string `json:"text,omitempty" validate:"max=5000"`
+}
+
+// UserScore is an aggregate of all user ratings of a movie
+type UserScore struct {
+	Mean  float64 `json:"mean"`
+	Count int     `json:"count"`
+	// Distribution holds number of ratings for every score, index is the score
+	Distribution []int `json:"distribution"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// MovieRevision is a version of movie saved by a change made to it, cast is versioned together with the movie.
+// Tags are not versioned, so revisions list current ones; crew and translations are left out.
+type MovieRevision struct {
+	Revision int `json:"revision"`
+	// Username is empty for revisions that existed before history was kept
+	Username  string    `json:"username,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Movie     Movie     `json:"movie"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+// YearCount is number of movies released in a year
+type YearCount struct {
+	Year  int `json:"year"`
+	Count int `json:"count"`
+}
+
+// RatingCount is number of movies or reviews with a rating
+type RatingCount struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
+
+// RatingDistribution holds counts of movie ratings and of user review ratings, from 0 to 10
+type RatingDistribution struct {
+	Movies  []RatingCount `json:"movies"`
+	Reviews []RatingCount `json:"reviews"`
+}
+
+// ProlificActor is an actor with number of movies they played in
+type ProlificActor struct {
+	ActorSummary
+	Movies int `json:"movies"`
+}
+
+// Summary describes distribution of numeric values, it is zero when there are no values
+type Summary struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Median  float64 `json:"median"`
+	Min     int     `json:"min"`
+	Max     int     `json:"max"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagLength is maximum number of characters in tag name
+const MaxTagLength = 50
+
+// Tag is a free-form label with number of movies and actors it is attached to
+type Tag struct {
+	Name   string `json:"name"`
+	Movies int    `json:"movies"`
+	Actors int    `json:"actors"`
+}
+
+type NewTag struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// NormalizeTag lowercases tag name and collapses whitespace, so that "Cult  Classic" and "cult classic" are the same tag.
+// It reports false for empty and too long names.
+func NormalizeTag(name string) (string, bool) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" || utf8.RuneCountInString(name) > MaxTagLength {
+		return "", false
+	}
+	return name, true
+}
+
+// NormalizeTags normalizes each of tag names dropping repeated ones, reporting false if any of them is invalid
+func NormalizeTags(names []string) ([]string, bool) {
+	if len(names) == 0 {
+		return nil, true
+	}
+
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, ok := NormalizeTag(name)
+		if !ok {
+			return nil, false
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, true
+}
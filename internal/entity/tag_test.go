@@ -0,0 +1,35 @@
+package entity_test
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+		ok   bool
+	}{
+		{name: "Simple", tag: "oscar-winner", want: "oscar-winner", ok: true},
+		{name: "Case and spaces", tag: "  Cult \t Classic ", want: "cult classic", ok: true},
+		{name: "Non-ASCII", tag: "Ужасы", want: "ужасы", ok: true},
+		{name: "Empty", tag: "   "},
+		{name: "Too long", tag: strings.Repeat("я", entity.MaxTagLength+1)},
+		{name: "Longest", tag: strings.Repeat("я", entity.MaxTagLength), want: strings.Repeat("я", entity.MaxTagLength), ok: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := entity.NormalizeTag(tt.tag)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
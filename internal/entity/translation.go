@@ -0,0 +1,22 @@
+package entity
+
+// MovieTranslation holds movie title and description in given language
+type MovieTranslation struct {
+	Lang string `json:"lang"`
+	NewMovieTranslation
+}
+
+type NewMovieTranslation struct {
+	Title       string `json:"title" validate:"required,max=150"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+}
+
+// ActorTranslation holds actor name in given language
+type ActorTranslation struct {
+	Lang string `json:"lang"`
+	NewActorTranslation
+}
+
+type NewActorTranslation struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
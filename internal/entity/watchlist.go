@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// WatchlistEntry represents a movie user wants to watch
+type WatchlistEntry struct {
+	Movie   Movie     `json:"movie"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// HistoryEntry represents a movie user has watched on a given date
+type HistoryEntry struct {
+	ID        int       `json:"id"`
+	Movie     Movie     `json:"movie"`
+	WatchedOn time.Time `json:"watched_on"`
+}
+
+type NewHistoryEntry struct {
+	MovieID   int       `json:"movie_id" validate:"required"`
+	WatchedOn time.Time `json:"watched_on"`
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"net/http"
+)
+
+// FromRequest returns who made request to be recorded with changes it makes.
+// Username is set by jwt middleware and request id by requestid middleware.
+func FromRequest(r *http.Request) entity.AuditInfo {
+	return entity.AuditInfo{
+		Username:  r.Header.Get("x-username"),
+		RequestID: r.Header.Get("X-Request-Id"),
+	}
+}
@@ -0,0 +1,104 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag returns strong entity tag of representation of given version, e.g. `"3-5d41402abc4b2a76"`.
+// Match only compares the version part, digest of representation tells apart responses
+// that differ by requested language or requesting user.
+func Tag(version int, representation any) (string, error) {
+	sum, err := digest(representation)
+	if err != nil {
+		return "", err
+	}
+	return `"` + strconv.Itoa(version) + "-" + sum + `"`, nil
+}
+
+// Digest returns strong entity tag of representation without version, such as a list
+func Digest(representation any) (string, error) {
+	sum, err := digest(representation)
+	if err != nil {
+		return "", err
+	}
+	return `"` + sum + `"`, nil
+}
+
+func digest(representation any) (string, error) {
+	encoded, err := json.Marshal(representation)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// Match reports whether If-Match header matches entity of given version.
+// Tags are compared strongly, so weak tags never match; `*` matches any entity.
+func Match(header string, version int) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	want := strconv.Itoa(version)
+	for _, candidate := range strings.Split(header, ",") {
+		opaque, ok := strings.CutPrefix(strings.TrimSpace(candidate), `"`)
+		if !ok {
+			continue
+		}
+		opaque, ok = strings.CutSuffix(opaque, `"`)
+		if !ok {
+			continue
+		}
+		if got, _, _ := strings.Cut(opaque, "-"); got == want {
+			return true
+		}
+	}
+	return false
+}
+
+// NoneMatch reports whether If-None-Match header matches tag, tags are compared weakly as for GET requests.
+func NoneMatch(header, tag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModified sets validators of representation on response and reports whether copy of the client is still fresh,
+// in which case 304 should be sent instead of the representation. If-None-Match takes precedence over
+// If-Modified-Since, zero modified time leaves Last-Modified out.
+func NotModified(w http.ResponseWriter, r *http.Request, tag string, modified time.Time) bool {
+	w.Header().Set("ETag", tag)
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		return NoneMatch(header, tag)
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !modified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err != nil {
+			return false
+		}
+		// Last-Modified has one second precision
+		return !modified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package etag_test
+
+import (
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version int
+		match   bool
+	}{
+		{name: "Same version", header: `"3"`, version: 3, match: true},
+		{name: "Other version", header: `"2"`, version: 3},
+		{name: "Any", header: "*", version: 3, match: true},
+		{name: "List", header: `"1", "3"`, version: 3, match: true},
+		{name: "Weak tag", header: `W/"3"`, version: 3},
+		{name: "Unquoted", header: `3`, version: 3},
+		{name: "Tag of representation", header: `"3-5d41402abc4b2a76"`, version: 3, match: true},
+		{name: "Tag of other version", header: `"33-5d41402abc4b2a76"`, version: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.match, etag.Match(tt.header, tt.version))
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	tag, err := etag.Tag(3, map[string]string{"title": "Alien"})
+	require.NoError(t, err)
+	require.Regexp(t, `^"3-[0-9a-f]{16}"$`, tag)
+	require.True(t, etag.Match(tag, 3))
+
+	other, err := etag.Tag(3, map[string]string{"title": "Aliens"})
+	require.NoError(t, err)
+	require.NotEqual(t, tag, other)
+
+	digest, err := etag.Digest([]string{"Alien"})
+	require.NoError(t, err)
+	require.Regexp(t, `^"[0-9a-f]{16}"$`, digest)
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     string
+		ifModifiedSince string
+		modified        time.Time
+		notModified     bool
+	}{
+		{name: "No preconditions", modified: modified},
+		{name: "Same tag", ifNoneMatch: `"3-a"`, modified: modified, notModified: true},
+		{name: "Weak same tag", ifNoneMatch: `W/"3-a"`, modified: modified, notModified: true},
+		{name: "Any", ifNoneMatch: "*", modified: modified, notModified: true},
+		{name: "Other tag", ifNoneMatch: `"2-a", "3-b"`, modified: modified},
+		{name: "Other tag modified since", ifNoneMatch: `"2-a"`, ifModifiedSince: "Wed, 01 May 2024 12:00:00 GMT", modified: modified},
+		{name: "Not modified since", ifModifiedSince: "Wed, 01 May 2024 12:00:00 GMT", modified: modified, notModified: true},
+		{name: "Modified since", ifModifiedSince: "Wed, 01 May 2024 11:59:59 GMT", modified: modified},
+		{name: "Bad date", ifModifiedSince: "yesterday", modified: modified},
+		{name: "Unknown modification time", ifModifiedSince: "Wed, 01 May 2024 12:00:00 GMT"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rr := httptest.NewRecorder()
+
+			require.Equal(t, tt.notModified, etag.NotModified(rr, req, `"3-a"`, tt.modified))
+			require.Equal(t, `"3-a"`, rr.Header().Get("ETag"))
+			if !tt.modified.IsZero() {
+				require.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
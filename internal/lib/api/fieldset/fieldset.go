@@ -0,0 +1,105 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Include reads comma-separated `include` query parameter, naming related resources to embed.
+// Relations other than allowed ones are rejected.
+func Include(query url.Values, allowed ...string) (map[string]bool, error) {
+	include := make(map[string]bool)
+	for _, name := range split(query.Get("include")) {
+		if !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("unknown relation %q", name)
+		}
+		include[name] = true
+	}
+	return include, nil
+}
+
+// Fields reads comma-separated `fields` query parameter of sparse fieldset, names are JSON names of top-level fields of v.
+// It returns nil when parameter is missing, meaning all fields.
+func Fields(query url.Values, v any) ([]string, error) {
+	fields := split(query.Get("fields"))
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	names := jsonNames(reflect.TypeOf(v))
+	for _, field := range fields {
+		if !slices.Contains(names, field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+	return fields, nil
+}
+
+// Select trims JSON objects of items to given fields, `id` is always kept so that items can be told apart.
+// Fields which are omitted from an item are missing in it as well.
+func Select[T any](items []T, fields []string) ([]map[string]json.RawMessage, error) {
+	selected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &object); err != nil {
+			return nil, err
+		}
+
+		trimmed := make(map[string]json.RawMessage, len(fields)+1)
+		for name, value := range object {
+			if name == "id" || slices.Contains(fields, name) {
+				trimmed[name] = value
+			}
+		}
+		selected = append(selected, trimmed)
+	}
+	return selected, nil
+}
+
+func split(param string) []string {
+	var names []string
+	for _, name := range strings.Split(param, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonNames returns JSON names of fields of struct type, including ones of embedded structs
+func jsonNames(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			names = append(names, jsonNames(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
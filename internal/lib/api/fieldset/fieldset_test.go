@@ -0,0 +1,115 @@
+package fieldset_test
+
+import (
+	"encoding/json"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/fieldset"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+)
+
+func TestInclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		include map[string]bool
+		wantErr bool
+	}{
+		{
+			name:    "Missing",
+			include: map[string]bool{},
+		},
+		{
+			name:    "Relation",
+			query:   "include=actors",
+			include: map[string]bool{"actors": true},
+		},
+		{
+			name:    "Unknown relation",
+			query:   "include=actors,reviews",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			include, err := fieldset.Include(query, "actors")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.include, include)
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		fields  []string
+		wantErr bool
+	}{
+		{
+			name: "Missing",
+		},
+		{
+			name:   "Own and embedded fields",
+			query:  "fields=title, rating,tags",
+			fields: []string{"title", "rating", "tags"},
+		},
+		{
+			name:    "Hidden field",
+			query:   "fields=title,Version",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown field",
+			query:   "fields=score",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			fields, err := fieldset.Fields(query, entity.Movie{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	movies := []entity.Movie{
+		{ID: 1, NewMovie: entity.NewMovie{Title: "Alien", Description: "In space", Rating: 8}},
+		{ID: 2, NewMovie: entity.NewMovie{Title: "Aliens", Rating: 9}},
+	}
+
+	selected, err := fieldset.Select(movies, []string{"title", "rating", "description"})
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(selected)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"id":1,"title":"Alien","description":"In space","rating":8},
+		{"id":2,"title":"Aliens","rating":9}
+	]`, string(encoded))
+}
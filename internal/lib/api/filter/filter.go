@@ -0,0 +1,50 @@
+package filter
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"net/url"
+	"strconv"
+)
+
+// Awards reads award filter shared by movie and actor lists from query parameters,
+// returning name of malformed parameter on error.
+func Awards(query url.Values) (entity.AwardFilter, string, error) {
+	var filter entity.AwardFilter
+
+	ints := []struct {
+		param string
+		dest  *int
+	}{
+		{"award_category", &filter.AwardCategoryID},
+		{"min_nominations", &filter.MinNominations},
+		{"min_wins", &filter.MinWins},
+	}
+	for _, p := range ints {
+		if value := query.Get(p.param); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return entity.AwardFilter{}, p.param, err
+			}
+			*p.dest = parsed
+		}
+	}
+
+	if value := query.Get("award_won"); value != "" {
+		won, err := strconv.ParseBool(value)
+		if err != nil {
+			return entity.AwardFilter{}, "award_won", err
+		}
+		filter.AwardWon = won
+	}
+
+	return filter, "", nil
+}
+
+// IncludeDeleted reads include_deleted query parameter, it is up to caller to allow it to admins only.
+func IncludeDeleted(query url.Values) (bool, error) {
+	value := query.Get("include_deleted")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
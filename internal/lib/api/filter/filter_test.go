@@ -0,0 +1,92 @@
+package filter_test
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/filter"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+)
+
+func TestAwards(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		filter entity.AwardFilter
+		param  string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:   "Winners of category",
+			query:  "award_category=3&award_won=true",
+			filter: entity.AwardFilter{AwardCategoryID: 3, AwardWon: true},
+		},
+		{
+			name:   "Counts",
+			query:  "min_nominations=5&min_wins=1",
+			filter: entity.AwardFilter{MinNominations: 5, MinWins: 1},
+		},
+		{
+			name:  "Bad category",
+			query: "award_category=a",
+			param: "award_category",
+		},
+		{
+			name:  "Bad won",
+			query: "award_won=maybe",
+			param: "award_won",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, param, err := filter.Awards(query)
+			require.Equal(t, tt.param, param)
+			if tt.param != "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.filter, got)
+		})
+	}
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    bool
+		wantErr bool
+	}{
+		{query: ""},
+		{query: "include_deleted=true", want: true},
+		{query: "include_deleted=0"},
+		{query: "include_deleted=maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, err := filter.IncludeDeleted(query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
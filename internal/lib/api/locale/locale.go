@@ -0,0 +1,88 @@
+package locale
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxLanguages limits number of accepted languages, so the header can't blow up queries
+const maxLanguages = 10
+
+var tagRegexp = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Normalize lowercases language tag, such as `pt-BR`, and reports whether it is well-formed
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return tag, tagRegexp.MatchString(tag)
+}
+
+// FromRequest returns languages requested by client, most preferred first.
+// `lang` query parameter takes precedence over Accept-Language header.
+// Each regional tag is followed by its primary language, so `pt-br` falls back to `pt`.
+func FromRequest(r *http.Request) []string {
+	if lang, ok := Normalize(r.URL.Query().Get("lang")); ok {
+		return withFallbacks([]string{lang})
+	}
+
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag, ok := Normalize(tag)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	langs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		langs = append(langs, tag.tag)
+	}
+
+	return withFallbacks(langs)
+}
+
+func withFallbacks(tags []string) []string {
+	var langs []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		if !seen[tag] && len(langs) < maxLanguages {
+			seen[tag] = true
+			langs = append(langs, tag)
+		}
+	}
+
+	for _, tag := range tags {
+		add(tag)
+		if primary, _, found := strings.Cut(tag, "-"); found {
+			add(primary)
+		}
+	}
+
+	return langs
+}
@@ -0,0 +1,59 @@
+package locale_test
+
+import (
+	"github.com/rmntim/movielab/internal/lib/api/locale"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		acceptLanguage string
+		langs          []string
+	}{
+		{
+			name: "Nothing requested",
+			url:  "/",
+		},
+		{
+			name:           "Query parameter wins",
+			url:            "/?lang=pt-BR",
+			acceptLanguage: "de",
+			langs:          []string{"pt-br", "pt"},
+		},
+		{
+			name:           "Invalid query parameter is ignored",
+			url:            "/?lang=%3Bdrop",
+			acceptLanguage: "de",
+			langs:          []string{"de"},
+		},
+		{
+			name:           "Ordered by quality",
+			url:            "/",
+			acceptLanguage: "en;q=0.5, fr-CA, fr;q=0.8, *;q=0.1",
+			langs:          []string{"fr-ca", "fr", "en"},
+		},
+		{
+			name:           "Zero quality and malformed entries are skipped",
+			url:            "/",
+			acceptLanguage: "ru;q=0, de;q=x, es",
+			langs:          []string{"es"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			require.Equal(t, tt.langs, locale.FromRequest(req))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+)
+
+// Media types of supported patch documents
+const (
+	// MergePatch is JSON Merge Patch, RFC 7396
+	MergePatch = "application/merge-patch+json"
+	// JSONPatch is JSON Patch, RFC 6902
+	JSONPatch = "application/json-patch+json"
+)
+
+// Accepted lists supported media types, it is sent as Accept-Patch header
+const Accepted = MergePatch + ", " + JSONPatch
+
+var (
+	// ErrUnsupportedType is returned for patch of unknown media type
+	ErrUnsupportedType = errors.New("unsupported patch type")
+	// ErrInvalidPatch is returned for malformed patch document
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrConflict is returned when patch doesn't apply to the document, e.g. its path is missing or test fails
+	ErrConflict = errors.New("patch does not apply")
+)
+
+// Apply applies patch of media type given by Content-Type header to JSON document and returns patched document.
+func Apply(contentType string, doc, patch []byte) ([]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedType
+	}
+
+	switch mediaType {
+	case MergePatch:
+		return ApplyMergePatch(doc, patch)
+	case JSONPatch:
+		return ApplyJSONPatch(doc, patch)
+	}
+
+	return nil, ErrUnsupportedType
+}
+
+// ApplyMergePatch applies JSON Merge Patch to document, null values of the patch remove members of the document.
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := decode(doc, &target); err != nil {
+		return nil, err
+	}
+
+	var values any
+	if err := decode(patch, &values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergePatch(target, values))
+}
+
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any)
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// decode unmarshals JSON keeping numbers as they are, so large integers don't lose precision
+func decode(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package patch_test
+
+import (
+	"github.com/rmntim/movielab/internal/lib/api/patch"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const movie = `{"title":"Alien","description":"In space","rating":8,"actor_ids":[1,2,3],"budget":{"amount":11000000,"currency":"USD"}}`
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		result      string
+		err         error
+	}{
+		{
+			name:        "Merge patch",
+			contentType: patch.MergePatch,
+			patch:       `{"title":"Aliens","description":null,"budget":{"amount":18500000}}`,
+			result:      `{"title":"Aliens","rating":8,"actor_ids":[1,2,3],"budget":{"amount":18500000,"currency":"USD"}}`,
+		},
+		{
+			name:        "Merge patch replaces arrays",
+			contentType: patch.MergePatch + "; charset=utf-8",
+			patch:       `{"actor_ids":[2]}`,
+			result:      `{"title":"Alien","description":"In space","rating":8,"actor_ids":[2],"budget":{"amount":11000000,"currency":"USD"}}`,
+		},
+		{
+			name:        "Malformed merge patch",
+			contentType: patch.MergePatch,
+			patch:       `{"title":`,
+			err:         patch.ErrInvalidPatch,
+		},
+		{
+			name:        "JSON patch of actor ids",
+			contentType: patch.JSONPatch,
+			patch: `[
+				{"op":"test","path":"/actor_ids/1","value":2.0},
+				{"op":"remove","path":"/actor_ids/1"},
+				{"op":"add","path":"/actor_ids/-","value":4},
+				{"op":"add","path":"/actor_ids/0","value":5}
+			]`,
+			result: `{"title":"Alien","description":"In space","rating":8,"actor_ids":[5,1,3,4],"budget":{"amount":11000000,"currency":"USD"}}`,
+		},
+		{
+			name:        "JSON patch of fields",
+			contentType: patch.JSONPatch,
+			patch: `[
+				{"op":"replace","path":"/title","value":"Alien 3"},
+				{"op":"remove","path":"/description"},
+				{"op":"copy","from":"/budget","path":"/box_office"},
+				{"op":"replace","path":"/box_office/amount","value":159800000},
+				{"op":"move","from":"/rating","path":"/score"}
+			]`,
+			result: `{"title":"Alien 3","actor_ids":[1,2,3],"score":8,"budget":{"amount":11000000,"currency":"USD"},"box_office":{"amount":159800000,"currency":"USD"}}`,
+		},
+		{
+			name:        "Escaped pointer",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"add","path":"/a~1b~0c","value":true}]`,
+			result:      `{"title":"Alien","description":"In space","rating":8,"actor_ids":[1,2,3],"budget":{"amount":11000000,"currency":"USD"},"a/b~c":true}`,
+		},
+		{
+			name:        "Failed test",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"test","path":"/title","value":"Aliens"},{"op":"remove","path":"/title"}]`,
+			err:         patch.ErrConflict,
+		},
+		{
+			name:        "Missing member",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"replace","path":"/runtime","value":117}]`,
+			err:         patch.ErrConflict,
+		},
+		{
+			name:        "Index out of bounds",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"remove","path":"/actor_ids/3"}]`,
+			err:         patch.ErrConflict,
+		},
+		{
+			name:        "Move into itself",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"move","from":"/budget","path":"/budget/old"}]`,
+			err:         patch.ErrConflict,
+		},
+		{
+			name:        "Bad index",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"remove","path":"/actor_ids/01"}]`,
+			err:         patch.ErrInvalidPatch,
+		},
+		{
+			name:        "Missing value",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"add","path":"/runtime"}]`,
+			err:         patch.ErrInvalidPatch,
+		},
+		{
+			name:        "Unknown operation",
+			contentType: patch.JSONPatch,
+			patch:       `[{"op":"append","path":"/actor_ids","value":4}]`,
+			err:         patch.ErrInvalidPatch,
+		},
+		{
+			name:        "Not a list of operations",
+			contentType: patch.JSONPatch,
+			patch:       `{"op":"remove","path":"/title"}`,
+			err:         patch.ErrInvalidPatch,
+		},
+		{
+			name:        "Plain JSON",
+			contentType: "application/json",
+			patch:       `{"title":"Aliens"}`,
+			err:         patch.ErrUnsupportedType,
+		},
+		{
+			name:  "No content type",
+			patch: `{"title":"Aliens"}`,
+			err:   patch.ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := patch.Apply(tt.contentType, []byte(movie), []byte(tt.patch))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.result, string(result))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package prefer
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Header names of preferences, RFC 7240
+const (
+	Header        = "Prefer"
+	AppliedHeader = "Preference-Applied"
+)
+
+// Idempotent asks DELETE to succeed when resource is already gone, so that retries get the same response.
+// Such deletes respond 204 No Content.
+const Idempotent = "idempotent"
+
+// Has tells whether request prefers given preference, parameters and values of preferences are ignored
+func Has(r *http.Request, preference string) bool {
+	for _, header := range r.Header.Values(Header) {
+		for _, token := range strings.Split(header, ",") {
+			name, _, _ := strings.Cut(token, ";")
+			name, _, _ = strings.Cut(name, "=")
+			if strings.EqualFold(strings.TrimSpace(name), preference) {
+				return true
+			}
+		}
+	}
+	return false
+}
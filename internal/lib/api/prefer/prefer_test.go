@@ -0,0 +1,54 @@
+package prefer_test
+
+import (
+	"github.com/rmntim/movielab/internal/lib/api/prefer"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		has     bool
+	}{
+		{
+			name:    "Single",
+			headers: []string{"idempotent"},
+			has:     true,
+		},
+		{
+			name:    "Among others",
+			headers: []string{"return=minimal, Idempotent; strict"},
+			has:     true,
+		},
+		{
+			name:    "Separate header",
+			headers: []string{"respond-async", " idempotent "},
+			has:     true,
+		},
+		{
+			name:    "Other preferences",
+			headers: []string{"return=idempotent, handling=lenient"},
+		},
+		{
+			name: "No header",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodDelete, "/", nil)
+			require.NoError(t, err)
+			for _, header := range tt.headers {
+				req.Header.Add(prefer.Header, header)
+			}
+
+			require.Equal(t, tt.has, prefer.Has(req, prefer.Idempotent))
+		})
+	}
+}
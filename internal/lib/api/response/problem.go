@@ -0,0 +1,193 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/storage"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is media type of error responses, RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix turns error code into problem type URI
+const problemTypePrefix = "urn:movielab:problem:"
+
+// Stable error codes, clients may branch on them, unlike on error messages
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeValidationFailed   = "validation_failed"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeConflict           = "conflict"
+	CodeGone               = "gone"
+	CodePreconditionFailed = "precondition_failed"
+	CodeUnsupportedType    = "unsupported_media_type"
+	CodeUnprocessable      = "unprocessable"
+	CodePreconditionNeeded = "precondition_required"
+	CodeRateLimited        = "rate_limited"
+	CodeClientError        = "client_error"
+	CodeInternal           = "internal"
+
+	CodeVersionConflict     = "version_conflict"
+	CodeInvalidReference    = "invalid_reference"
+	CodeConstraintViolation = "constraint_violation"
+)
+
+// Problem is error response, RFC 7807, extended with error code, request id and invalid fields
+type Problem struct {
+	// Type is URI of the problem type, it is derived from Code
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	// Status is HTTP status code of the response
+	Status int `json:"status"`
+	// Detail is human-readable explanation of this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is path of the request
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+// NewProblem returns problem of response status, code defaults to one derived from status
+func NewProblem(status int, code, detail string) Problem {
+	if code == "" {
+		code = DefaultCode(status)
+	}
+
+	return Problem{
+		Type:   problemTypePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// DefaultCode returns code of errors which don't have more specific one
+func DefaultCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedType
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case http.StatusPreconditionRequired:
+		return CodePreconditionNeeded
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeClientError
+}
+
+// storageErrors maps storage errors to response status and code
+var storageErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{storage.ErrMovieNotFound, http.StatusNotFound, "movie_not_found"},
+	{storage.ErrRevisionNotFound, http.StatusNotFound, "revision_not_found"},
+	{storage.ErrVersionConflict, http.StatusPreconditionFailed, CodeVersionConflict},
+	{storage.ErrActorNotFound, http.StatusNotFound, "actor_not_found"},
+	{storage.ErrPathNotFound, http.StatusNotFound, "path_not_found"},
+	{storage.ErrPersonNotFound, http.StatusNotFound, "person_not_found"},
+	{storage.ErrCreditNotFound, http.StatusNotFound, "credit_not_found"},
+	{storage.ErrCreditExists, http.StatusConflict, "credit_exists"},
+	{storage.ErrReviewNotFound, http.StatusNotFound, "review_not_found"},
+	{storage.ErrReviewExists, http.StatusConflict, "review_exists"},
+	{storage.ErrWatchlistEntryNotFound, http.StatusNotFound, "watchlist_entry_not_found"},
+	{storage.ErrHistoryEntryNotFound, http.StatusNotFound, "history_entry_not_found"},
+	{storage.ErrCollectionNotFound, http.StatusNotFound, "collection_not_found"},
+	{storage.ErrCollectionEntryNotFound, http.StatusNotFound, "collection_entry_not_found"},
+	{storage.ErrCollectionEntryExists, http.StatusConflict, "collection_entry_exists"},
+	{storage.ErrTranslationNotFound, http.StatusNotFound, "translation_not_found"},
+	{storage.ErrTagNotFound, http.StatusNotFound, "tag_not_found"},
+	{storage.ErrAwardNotFound, http.StatusNotFound, "award_not_found"},
+	{storage.ErrAwardExists, http.StatusConflict, "award_exists"},
+	{storage.ErrAwardCategoryNotFound, http.StatusNotFound, "award_category_not_found"},
+	{storage.ErrAwardCategoryExists, http.StatusConflict, "award_category_exists"},
+	{storage.ErrCeremonyExists, http.StatusConflict, "ceremony_exists"},
+	{storage.ErrNominationNotFound, http.StatusNotFound, "nomination_not_found"},
+}
+
+// StorageError maps error returned by storage to response status and error response.
+// Constraint violations are reported with the offending field when storage knows it.
+// Errors which are not known are internal ones, they are responded with status 500 and fallback message.
+func StorageError(err error, fallback string) (int, Response) {
+	var constraintErr *storage.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return constraintError(constraintErr)
+	}
+
+	for _, known := range storageErrors {
+		if errors.Is(err, known.err) {
+			return known.status, ErrorCode(known.code, capitalize(known.err.Error()))
+		}
+	}
+
+	return http.StatusInternalServerError, ErrorCode(CodeInternal, fallback)
+}
+
+// constraintError maps missing references to 422, duplicates to 409, missing values to 400 and other violations to 422
+func constraintError(err *storage.ConstraintError) (int, Response) {
+	status, code := http.StatusUnprocessableEntity, CodeConstraintViolation
+	field := FieldError{Field: err.Field, Rule: "check", Message: "is not allowed"}
+	switch {
+	case errors.Is(err, storage.ErrReferenceNotFound):
+		status, code = http.StatusUnprocessableEntity, CodeInvalidReference
+		field.Rule, field.Message = "exists", "references missing record"
+		if err.Value != "" {
+			field.Message = fmt.Sprintf("record %s does not exist", err.Value)
+		}
+	case errors.Is(err, storage.ErrDuplicate):
+		status, code = http.StatusConflict, CodeConflict
+		field.Rule, field.Message = "unique", "is already taken"
+		if err.Value != "" {
+			field.Message = fmt.Sprintf("value %s is already taken", err.Value)
+		}
+	case errors.Is(err, storage.ErrValueRequired):
+		status, code = http.StatusBadRequest, CodeValidationFailed
+		field.Rule, field.Message = "required", "is required"
+	}
+
+	if err.Field == "" {
+		return status, ErrorCode(code, capitalize(err.Error()))
+	}
+
+	return status, Response{
+		Status: StatusError,
+		Error:  fmt.Sprintf("field %s is invalid", err.Field),
+		Code:   code,
+		Fields: []FieldError{field},
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,132 @@
+package response_test
+
+import (
+	"errors"
+	"fmt"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestStorageError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		respCode int
+		resp     resp.Response
+	}{
+		{
+			name:     "Not found",
+			err:      fmt.Errorf("storage.postgres.GetMovieById: %w", storage.ErrMovieNotFound),
+			respCode: http.StatusNotFound,
+			resp:     resp.ErrorCode("movie_not_found", "Movie not found"),
+		},
+		{
+			name:     "Exists",
+			err:      storage.ErrReviewExists,
+			respCode: http.StatusConflict,
+			resp:     resp.ErrorCode("review_exists", "Review already exists"),
+		},
+		{
+			name:     "Version conflict",
+			err:      storage.ErrVersionConflict,
+			respCode: http.StatusPreconditionFailed,
+			resp:     resp.ErrorCode(resp.CodeVersionConflict, "Version conflict"),
+		},
+		{
+			name: "Missing reference",
+			err: fmt.Errorf("storage.postgres.CreateMovie: %w", &storage.ConstraintError{
+				Err:        storage.ErrReferenceNotFound,
+				Constraint: "movie_actors_actor_id_fkey",
+				Field:      "actor_ids",
+				Value:      "5",
+			}),
+			respCode: http.StatusUnprocessableEntity,
+			resp: resp.Response{
+				Status: resp.StatusError,
+				Error:  "field actor_ids is invalid",
+				Code:   resp.CodeInvalidReference,
+				Fields: []resp.FieldError{{Field: "actor_ids", Rule: "exists", Message: "record 5 does not exist"}},
+			},
+		},
+		{
+			name:     "Check violation of field",
+			err:      &storage.ConstraintError{Err: storage.ErrConstraintViolated, Constraint: "rating_check", Field: "rating"},
+			respCode: http.StatusUnprocessableEntity,
+			resp: resp.Response{
+				Status: resp.StatusError,
+				Error:  "field rating is invalid",
+				Code:   resp.CodeConstraintViolation,
+				Fields: []resp.FieldError{{Field: "rating", Rule: "check", Message: "is not allowed"}},
+			},
+		},
+		{
+			name:     "Missing value",
+			err:      &storage.ConstraintError{Err: storage.ErrValueRequired, Field: "title"},
+			respCode: http.StatusBadRequest,
+			resp: resp.Response{
+				Status: resp.StatusError,
+				Error:  "field title is invalid",
+				Code:   resp.CodeValidationFailed,
+				Fields: []resp.FieldError{{Field: "title", Rule: "required", Message: "is required"}},
+			},
+		},
+		{
+			name:     "Duplicate of unknown field",
+			err:      &storage.ConstraintError{Err: storage.ErrDuplicate, Constraint: "tags_name_key", Value: "noir"},
+			respCode: http.StatusConflict,
+			resp:     resp.ErrorCode(resp.CodeConflict, "Duplicate record: noir"),
+		},
+		{
+			name:     "Wrapped duplicate",
+			err:      fmt.Errorf("storage.postgres.CreateActor: %w", &storage.ConstraintError{Err: storage.ErrDuplicate}),
+			respCode: http.StatusConflict,
+			resp:     resp.ErrorCode(resp.CodeConflict, "Duplicate record"),
+		},
+		{
+			name:     "Missing reference",
+			err:      &storage.ConstraintError{Err: storage.ErrReferenceNotFound},
+			respCode: http.StatusUnprocessableEntity,
+			resp:     resp.ErrorCode(resp.CodeInvalidReference, "Referenced record not found"),
+		},
+		{
+			name:     "Check violation",
+			err:      &storage.ConstraintError{Err: storage.ErrConstraintViolated},
+			respCode: http.StatusUnprocessableEntity,
+			resp:     resp.ErrorCode(resp.CodeConstraintViolation, "Constraint violated"),
+		},
+		{
+			name:     "Unknown error",
+			err:      errors.New("connection refused"),
+			respCode: http.StatusInternalServerError,
+			resp:     resp.ErrorCode(resp.CodeInternal, "Failed to create movie"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, errResp := resp.StorageError(tt.err, "Failed to create movie")
+			require.Equal(t, tt.respCode, code)
+			require.Equal(t, tt.resp, errResp)
+		})
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	problem := resp.NewProblem(http.StatusNotFound, "", "Movie not found")
+	require.Equal(t, resp.Problem{
+		Type:   "urn:movielab:problem:not_found",
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: "Movie not found",
+		Code:   resp.CodeNotFound,
+	}, problem)
+
+	problem = resp.NewProblem(http.StatusTeapot, "", "")
+	require.Equal(t, resp.CodeClientError, problem.Code)
+}
@@ -3,12 +3,29 @@ package response
 import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"reflect"
 	"strings"
 )
 
 type Response struct {
 	Status string `json:"status"` // Error | Ok
 	Error  string `json:"error,omitempty"`
+	// Code is stable error code, see Code constants, it is derived from response status when empty
+	Code string `json:"code,omitempty"`
+	// Fields detail validation errors of request fields
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes failed validation of a single request field
+type FieldError struct {
+	// Field is path of the field in request, e.g. `budget.currency` or `actor_ids[1]`
+	Field string `json:"field"`
+	// Rule is name of the failed rule, e.g. `required` or `max`
+	Rule string `json:"rule"`
+	// Param is parameter of the rule, e.g. maximum length
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
 }
 
 const (
@@ -29,20 +46,86 @@ func Error(msg string) Response {
 	}
 }
 
+// ErrorCode returns error response with stable code clients can branch on
+func ErrorCode(code, msg string) Response {
+	return Response{
+		Status: StatusError,
+		Error:  msg,
+		Code:   code,
+	}
+}
+
 func ValidationError(errs validator.ValidationErrors) Response {
 	var errMsgs []string
+	fields := make([]FieldError, 0, len(errs))
 
 	for _, err := range errs {
 		switch err.ActualTag() {
 		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is required", err.Field()))
+			errMsgs = append(errMsgs, fmt.Sprintf("field %s is required", err.StructField()))
 		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is invalid", err.Field()))
+			errMsgs = append(errMsgs, fmt.Sprintf("field %s is invalid", err.StructField()))
 		}
+
+		fields = append(fields, FieldError{
+			Field:   validate.Field(err),
+			Rule:    err.ActualTag(),
+			Param:   err.Param(),
+			Message: fieldMessage(err),
+		})
+	}
+
+	return Response{
+		Status: StatusError,
+		Error:  strings.Join(errMsgs, ", "),
+		Code:   CodeValidationFailed,
+		Fields: fields,
+	}
+}
+
+// InvalidFields returns error response for fields that failed checks done outside of validator, such as lookups
+func InvalidFields(fields []FieldError) Response {
+	errMsgs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		errMsgs = append(errMsgs, fmt.Sprintf("field %s is invalid", field.Field))
 	}
 
 	return Response{
 		Status: StatusError,
 		Error:  strings.Join(errMsgs, ", "),
+		Code:   CodeValidationFailed,
+		Fields: fields,
+	}
+}
+
+// fieldMessage describes failed rule of the field in human-readable form
+func fieldMessage(err validator.FieldError) string {
+	unit := ""
+	if err.Kind() == reflect.String {
+		unit = " characters"
+	} else if err.Kind() == reflect.Slice || err.Kind() == reflect.Map {
+		unit = " items"
+	}
+
+	switch err.ActualTag() {
+	case "required":
+		return "is required"
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", err.Param(), unit)
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", err.Param(), unit)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", err.Param())
+	case "len":
+		return fmt.Sprintf("must be exactly %s%s", err.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", err.Param())
+	case "gtfield":
+		return fmt.Sprintf("must be after %s", err.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "notfuture":
+		return "must not be in the future"
 	}
+	return "is invalid"
 }
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// validate is shared, since validator caches parsed struct tags
+var validate = newValidator()
+
+// embedded names embedded structs in namespaces, their fields are inline in requests
+const embedded = "-"
+
+// newValidator returns validator that reports fields by their JSON names, or names of query parameters
+// given with `query` tag, and knows custom tags:
+//   - notfuture: time is not after now
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"json", "query"} {
+			name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		if field.Anonymous {
+			return embedded
+		}
+		return ""
+	})
+
+	// Tag names are constant, so registration can't fail
+	_ = v.RegisterValidation("notfuture", notFuture)
+
+	return v
+}
+
+func notFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && !t.After(time.Now())
+}
+
+// Struct validates request struct by its validate tags, returning validator.ValidationErrors if it is invalid.
+func Struct(s any) error {
+	return validate.Struct(s)
+}
+
+// Field returns path of invalid field in request, e.g. `budget.currency` or `actor_ids[1]`
+func Field(err validator.FieldError) string {
+	// Namespace starts with name of validated struct, which is not part of request
+	segments := strings.Split(err.Namespace(), ".")[1:]
+
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment != embedded {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(path, ".")
+}
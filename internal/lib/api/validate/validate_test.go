@@ -0,0 +1,95 @@
+package validate_test
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStruct(t *testing.T) {
+	past := time.Date(1949, 10, 8, 0, 0, 0, 0, time.UTC)
+	future := time.Now().AddDate(1, 0, 0)
+
+	tests := []struct {
+		name string
+		s    any
+		// fields are expected request paths of failed fields
+		fields []string
+	}{
+		{
+			name: "Valid movie",
+			s:    entity.NewMovie{Title: "Alien", Rating: 8, ActorIDs: []int32{1, 2}},
+		},
+		{
+			name:   "Movie out of range",
+			s:      entity.NewMovie{Title: strings.Repeat("a", 151), Description: strings.Repeat("a", 1001), Rating: -1},
+			fields: []string{"title", "description", "rating"},
+		},
+		{
+			name:   "Movie with bad actor id and budget",
+			s:      entity.NewMovie{Title: "Alien", ActorIDs: []int32{1, -1}, Budget: &entity.Money{Amount: 1}},
+			fields: []string{"actor_ids[1]", "budget.currency"},
+		},
+		{
+			name: "Valid actor",
+			s:    entity.NewActor{Name: "Sigourney Weaver", Sex: "female", BirthDate: past},
+		},
+		{
+			name:   "Actor born in the future",
+			s:      entity.NewActor{Name: "Sigourney Weaver", Sex: "female", BirthDate: future},
+			fields: []string{"birthdate"},
+		},
+		{
+			name:   "Actor died in the future",
+			s:      entity.NewActor{Name: "Sigourney Weaver", Sex: "female", BirthDate: past, DeathDate: &future},
+			fields: []string{"deathdate"},
+		},
+		{
+			name:   "Actor without name",
+			s:      entity.NewActor{Name: "", Sex: "female", BirthDate: past, Gender: strings.Repeat("a", 51)},
+			fields: []string{"name", "gender"},
+		},
+		{
+			name:   "Actor with unknown sex",
+			s:      entity.NewActor{Name: "Sigourney Weaver", Sex: "unknown", BirthDate: past},
+			fields: []string{"sex"},
+		},
+		{
+			name:   "Filter out of range",
+			s:      entity.MovieFilter{MinRuntime: -1, AwardFilter: entity.AwardFilter{MinWins: -1}},
+			fields: []string{"runtime_min", "min_wins"},
+		},
+		{
+			name:   "Filter of unknown action",
+			s:      entity.AuditFilter{Action: "rename"},
+			fields: []string{"action"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate.Struct(tt.s)
+			if tt.fields == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErrs validator.ValidationErrors
+			require.True(t, errors.As(err, &validationErrs))
+
+			fields := make([]string, 0, len(validationErrs))
+			for _, fieldErr := range validationErrs {
+				fields = append(fields, validate.Field(fieldErr))
+			}
+			require.Equal(t, tt.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+// Cache keeps loaded values for a fixed time. Zero value and nil cache load value on every call.
+type Cache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]entry),
+	}
+}
+
+// Get returns cached value for key, calling load if value is missing or expired.
+// Errors are not cached.
+func Get[V any](c *Cache, key string, load func() (V, error)) (V, error) {
+	if c == nil || c.ttl <= 0 {
+		return load()
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(e.expires) {
+		if value, ok := e.value.(V); ok {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry{value: value, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return value, nil
+}
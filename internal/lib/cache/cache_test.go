@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(time.Minute)
+	c.now = func() time.Time { return now }
+
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	value, err := Get(c, "key", load)
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	value, err = Get(c, "key", load)
+	require.NoError(t, err)
+	require.Equal(t, 1, value, "value is cached")
+
+	value, err = Get(c, "other", load)
+	require.NoError(t, err)
+	require.Equal(t, 2, value, "keys are cached separately")
+
+	now = now.Add(time.Minute)
+	value, err = Get(c, "key", load)
+	require.NoError(t, err)
+	require.Equal(t, 3, value, "expired value is reloaded")
+
+	now = now.Add(time.Minute)
+	_, err = Get(c, "key", func() (int, error) { return 0, errors.New("failed") })
+	require.Error(t, err)
+	value, err = Get(c, "key", load)
+	require.NoError(t, err)
+	require.Equal(t, 4, value, "errors are not cached")
+}
+
+func TestGetDisabled(t *testing.T) {
+	for _, c := range []*Cache{nil, New(0)} {
+		calls := 0
+		load := func() (int, error) {
+			calls++
+			return calls, nil
+		}
+
+		_, _ = Get(c, "key", load)
+		value, err := Get(c, "key", load)
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+	}
+}
@@ -0,0 +1,188 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage/blob"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxPixels limits decoded image size, so small but highly compressed uploads can't exhaust memory
+	maxPixels = 50_000_000
+
+	thumbnailQuality = 85
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+)
+
+// contentTypes lists accepted upload formats, as detected by http.DetectContentType
+var contentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Uploader stores images with their thumbnails in blob store
+type Uploader struct {
+	store  blob.Store
+	widths []int
+}
+
+// NewUploader creates uploader producing thumbnails of given widths
+func NewUploader(store blob.Store, widths []int) *Uploader {
+	return &Uploader{store: store, widths: widths}
+}
+
+// Upload validates image, generates thumbnails and stores everything under a new version of given prefix.
+// Previously uploaded images are kept, so they can be discarded once the new one is saved.
+// Thumbnails wider than original are not generated.
+func (u *Uploader) Upload(prefix string, data []byte) (*entity.Image, error) {
+	const op = "lib.imaging.Upload"
+
+	contentType := http.DetectContentType(data)
+	if !contentTypes[contentType] {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalidImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	// Every upload gets its own keys, so clients never get cached files of a replaced image
+	prefix += "/" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	bounds := src.Bounds()
+	img := &entity.Image{
+		Width:      bounds.Dx(),
+		Height:     bounds.Dy(),
+		Thumbnails: make(map[int]string),
+		Prefix:     prefix,
+	}
+
+	key := prefix + "/original"
+	if err := u.store.Put(key, contentType, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, u.discard(prefix, err))
+	}
+	img.URL = u.store.URL(key)
+
+	for _, width := range u.widths {
+		if width <= 0 || width >= bounds.Dx() {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, Thumbnail(src, width), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, u.discard(prefix, err))
+		}
+
+		key := prefix + "/" + strconv.Itoa(width)
+		if err := u.store.Put(key, "image/jpeg", &buf); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, u.discard(prefix, err))
+		}
+		img.Thumbnails[width] = u.store.URL(key)
+	}
+
+	return img, nil
+}
+
+// discard removes partially uploaded image, returning err joined with failure of the removal
+func (u *Uploader) discard(prefix string, err error) error {
+	return errors.Join(err, u.store.DeleteAll(prefix))
+}
+
+// Delete removes all versions of image stored under given prefix
+func (u *Uploader) Delete(prefix string) error {
+	const op = "lib.imaging.Delete"
+
+	if err := u.store.DeleteAll(prefix); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Discard removes single uploaded version of image, nil image and images without prefix are ignored
+func (u *Uploader) Discard(image *entity.Image) error {
+	const op = "lib.imaging.Discard"
+
+	if image == nil || image.Prefix == "" {
+		return nil
+	}
+
+	if err := u.store.DeleteAll(image.Prefix); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Thumbnail scales image down to given width keeping aspect ratio.
+// Each resulting pixel is an average of source pixels it covers, transparent areas become white.
+func Thumbnail(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	height := max(1, sh*width/sw)
+
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			// Colors are alpha-premultiplied, so blending over white only adds remaining coverage
+			white := 255*n - a
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8((r + white) / n)
+			dst.Pix[i+1] = uint8((g + white) / n)
+			dst.Pix[i+2] = uint8((b + white) / n)
+			dst.Pix[i+3] = 255
+		}
+	}
+
+	return dst
+}
+
+// MoviePosterPrefix returns blob key prefix of movie poster
+func MoviePosterPrefix(movieID int) string {
+	return fmt.Sprintf("movies/%d/poster", movieID)
+}
+
+// ActorHeadshotPrefix returns blob key prefix of actor headshot
+func ActorHeadshotPrefix(actorID int) string {
+	return fmt.Sprintf("actors/%d/headshot", actorID)
+}
@@ -0,0 +1,126 @@
+package imaging_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/imaging"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+type memoryStore struct {
+	objects map[string][]byte
+	// failSuffix makes puts of keys ending with it fail
+	failSuffix string
+}
+
+func (s *memoryStore) Put(key, _ string, data io.Reader) error {
+	if s.failSuffix != "" && strings.HasSuffix(key, s.failSuffix) {
+		return errors.New("store is unavailable")
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = b
+	return nil
+}
+
+func (s *memoryStore) DeleteAll(prefix string) error {
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix+"/") {
+			delete(s.objects, key)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) URL(key string) string {
+	return "/images/" + key
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Parallel()
+
+	src := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			src.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	// Fully transparent column becomes white
+	for y := 0; y < 20; y++ {
+		src.Set(0, y, color.NRGBA{})
+		src.Set(1, y, color.NRGBA{})
+	}
+
+	thumb := imaging.Thumbnail(src, 20)
+
+	require.Equal(t, image.Rect(0, 0, 20, 10), thumb.Bounds())
+	require.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, thumb.At(0, 0))
+	require.Equal(t, color.RGBA{R: 255, A: 255}, thumb.At(10, 5))
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryStore{objects: map[string][]byte{
+		"movies/1/poster/old/original": []byte("old"),
+	}}
+	uploader := imaging.NewUploader(store, []int{10, 50})
+
+	data := encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 40, 60)))
+	img, err := uploader.Upload("movies/1/poster", data)
+	require.NoError(t, err)
+
+	require.Equal(t, 40, img.Width)
+	require.Equal(t, 60, img.Height)
+	require.True(t, strings.HasPrefix(img.Prefix, "movies/1/poster/"))
+	require.NotEqual(t, "movies/1/poster/old", img.Prefix)
+	require.Equal(t, "/images/"+img.Prefix+"/original", img.URL)
+	require.Equal(t, map[int]string{10: "/images/" + img.Prefix + "/10"}, img.Thumbnails)
+
+	require.Equal(t, data, store.objects[img.Prefix+"/original"])
+	require.Contains(t, store.objects, img.Prefix+"/10")
+	require.NotContains(t, store.objects, img.Prefix+"/50")
+	// Previous image is kept until it's discarded
+	require.Contains(t, store.objects, "movies/1/poster/old/original")
+
+	require.NoError(t, uploader.Discard(&entity.Image{Prefix: "movies/1/poster/old"}))
+	require.NotContains(t, store.objects, "movies/1/poster/old/original")
+	require.Contains(t, store.objects, img.Prefix+"/original")
+
+	require.NoError(t, uploader.Delete("movies/1/poster"))
+	require.Empty(t, store.objects)
+
+	_, err = uploader.Upload("movies/1/poster", []byte("not an image"))
+	require.ErrorIs(t, err, imaging.ErrUnsupportedType)
+
+	// Valid signature, but corrupted data
+	_, err = uploader.Upload("movies/1/poster", data[:20])
+	require.ErrorIs(t, err, imaging.ErrInvalidImage)
+}
+
+func TestUploadFailure(t *testing.T) {
+	t.Parallel()
+
+	store := &memoryStore{objects: map[string][]byte{}, failSuffix: "/10"}
+	uploader := imaging.NewUploader(store, []int{10})
+
+	_, err := uploader.Upload("movies/1/poster", encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 40, 60))))
+	require.Error(t, err)
+	// Original stored before the failure is removed
+	require.Empty(t, store.objects)
+}
@@ -0,0 +1,43 @@
+package purge
+
+import (
+	"context"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeDeleted(deletedBefore time.Time) (int64, error)
+}
+
+// Run purges rows deleted longer than retention ago right away and then every interval, until ctx is done.
+// Zero interval disables purging.
+func Run(ctx context.Context, log *slog.Logger, purger Purger, interval, retention time.Duration) {
+	const op = "lib.purge.Run"
+
+	log = log.With(slog.String("op", op))
+
+	if interval <= 0 {
+		log.Info("Purging of deleted rows is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := purger.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			log.Error("Failed to purge deleted rows", sl.Err(err))
+		} else if purged > 0 {
+			log.Info("Purged deleted rows", slog.Int64("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type purgerFunc func(deletedBefore time.Time) (int64, error)
+
+func (f purgerFunc) PurgeDeleted(deletedBefore time.Time) (int64, error) {
+	return f(deletedBefore)
+}
+
+func TestRun(t *testing.T) {
+	calls := make(chan time.Time)
+	purger := purgerFunc(func(deletedBefore time.Time) (int64, error) {
+		calls <- deletedBefore
+		return 0, errors.New("failures do not stop purging")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Run(ctx, slogdiscard.NewDiscardLogger(), purger, time.Millisecond, time.Hour)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		deletedBefore := <-calls
+		require.WithinDuration(t, time.Now().Add(-time.Hour), deletedBefore, time.Minute)
+	}
+
+	cancel()
+	for {
+		select {
+		case <-calls:
+		case <-done:
+			return
+		}
+	}
+}
+
+func TestRunDisabled(t *testing.T) {
+	purger := purgerFunc(func(time.Time) (int64, error) {
+		t.Fatal("purge must not run with zero interval")
+		return 0, nil
+	})
+
+	Run(context.Background(), slogdiscard.NewDiscardLogger(), purger, 0, time.Hour)
+}
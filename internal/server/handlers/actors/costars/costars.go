@@ -0,0 +1,81 @@
+package costars
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CostarsGetter
+type CostarsGetter interface {
+	GetCostars(actorID, limit, offset int) ([]entity.Costar, error)
+}
+
+type Response struct {
+	resp.Response
+	Costars []entity.Costar `json:"costars"`
+}
+
+func New(log *slog.Logger, costarsGetter CostarsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.costars.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		costars, err := costarsGetter.GetCostars(id, limit, offset)
+		if err != nil {
+			log.Error("Failed to get costars", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get costars")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if costars == nil {
+			costars = []entity.Costar{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Costars:  costars,
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package costars_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/costars"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/costars/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorCostars(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		query     string
+		limit     int
+		offset    int
+		mockBody  []entity.Costar
+		respBody  []entity.Costar
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:  "Success",
+			id:    "1",
+			limit: 10,
+			mockBody: []entity.Costar{
+				{ActorSummary: entity.ActorSummary{ID: 2, Name: "B"}, SharedMovies: 2, MovieIDs: []int32{1, 2}},
+			},
+			respBody: []entity.Costar{
+				{ActorSummary: entity.ActorSummary{ID: 2, Name: "B"}, SharedMovies: 2, MovieIDs: []int32{1, 2}},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No costars",
+			id:       "1",
+			query:    "limit=5&offset=5",
+			limit:    5,
+			offset:   5,
+			respBody: []entity.Costar{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad limit",
+			id:        "1",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			id:        "1",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "Actor not found",
+			id:        "1",
+			limit:     10,
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "GetCostars error",
+			id:        "1",
+			limit:     10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get costars",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			costarsGetterMock := mocks.NewCostarsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				costarsGetterMock.
+					On("GetCostars", 1, tt.limit, tt.offset).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := costars.New(slogdiscard.NewDiscardLogger(), costarsGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}/costars", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/costars?%s", tt.id, tt.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp costars.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Costars)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
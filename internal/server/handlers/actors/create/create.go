@@ -1,17 +1,22 @@
 package create
 
 import (
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorCreator
 type ActorCreator interface {
-	CreateActor(actor *entity.NewActor) (int, error)
+	CreateActor(actor *entity.NewActor, audit entity.AuditInfo) (int, error)
 }
 
 type Response struct {
@@ -40,17 +45,27 @@ func New(log *slog.Logger, actorCreator ActorCreator) http.HandlerFunc {
 			return
 		}
 
-		id, err := actorCreator.CreateActor(&actor)
+		if err := validate.Struct(actor); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		id, err := actorCreator.CreateActor(&actor, audit.FromRequest(r))
 		if err != nil {
 			log.Error("Failed to create actor", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to create actor"))
+			status, errResp := resp.StorageError(err, "Failed to create actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
 		render.JSON(w, r, Response{
 			Response: resp.Ok(),
-			Actor:    &entity.Actor{ID: id, NewActor: actor},
+			Actor:    &entity.Actor{ID: id, NewActor: actor, Age: actor.AgeAt(time.Now())},
 		})
 	}
 }
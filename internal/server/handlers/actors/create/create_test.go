@@ -24,16 +24,74 @@ func TestMovieCreate(t *testing.T) {
 		respCode  int
 		respError string
 		mockError error
+		respAge   int
 	}{
 		{
 			name: "Success",
 			reqActor: &entity.NewActor{
 				Name:      "Test",
-				Sex:       "Test",
+				Sex:       "male",
+				Gender:    "Test",
 				BirthDate: time.Now(),
 			},
 			respCode: http.StatusOK,
 		},
+		{
+			name: "Success with biography",
+			reqActor: &entity.NewActor{
+				Name:       "Test",
+				Sex:        "male",
+				BirthDate:  time.Date(1950, time.June, 1, 0, 0, 0, 0, time.UTC),
+				DeathDate:  ptr(time.Date(2000, time.May, 31, 0, 0, 0, 0, time.UTC)),
+				Birthplace: "Test",
+				Biography:  "Test",
+				Aliases:    []string{"Test"},
+			},
+			respCode: http.StatusOK,
+			respAge:  49,
+		},
+		{
+			name: "Died before birth",
+			reqActor: &entity.NewActor{
+				Name:      "Test",
+				Sex:       "male",
+				BirthDate: time.Date(1950, time.June, 1, 0, 0, 0, 0, time.UTC),
+				DeathDate: ptr(time.Date(1900, time.June, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field DeathDate is invalid",
+		},
+		{
+			name: "Born in the future",
+			reqActor: &entity.NewActor{
+				Name:      "Test",
+				Sex:       "male",
+				BirthDate: time.Now().AddDate(1, 0, 0),
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field BirthDate is invalid",
+		},
+		{
+			name: "Empty alias",
+			reqActor: &entity.NewActor{
+				Name:      "Test",
+				Sex:       "male",
+				BirthDate: time.Now(),
+				Aliases:   []string{""},
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field Aliases[0] is required",
+		},
+		{
+			name: "Unknown sex",
+			reqActor: &entity.NewActor{
+				Name:      "Test",
+				Sex:       "Test",
+				BirthDate: time.Now(),
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field Sex is invalid",
+		},
 		{
 			name:      "Unauthorized",
 			role:      "user",
@@ -44,7 +102,8 @@ func TestMovieCreate(t *testing.T) {
 			name: "CreateActor Error",
 			reqActor: &entity.NewActor{
 				Name:      "Test",
-				Sex:       "Test",
+				Sex:       "male",
+				Gender:    "Test",
 				BirthDate: time.Now(),
 			},
 			respCode:  http.StatusInternalServerError,
@@ -61,7 +120,7 @@ func TestMovieCreate(t *testing.T) {
 			actorsCreatorMock := mocks.NewActorCreator(t)
 
 			if tt.respError == "" || tt.mockError != nil {
-				actorsCreatorMock.On("CreateActor", mock.AnythingOfType("*entity.NewActor")).Return(1, tt.mockError).Once()
+				actorsCreatorMock.On("CreateActor", mock.AnythingOfType("*entity.NewActor"), entity.AuditInfo{Username: "admin", RequestID: "req-1"}).Return(1, tt.mockError).Once()
 			}
 
 			handler := create.New(slogdiscard.NewDiscardLogger(), actorsCreatorMock)
@@ -80,6 +139,8 @@ func TestMovieCreate(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
@@ -89,6 +150,13 @@ func TestMovieCreate(t *testing.T) {
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
 			require.Equal(t, tt.respError, resp.Error)
+			if tt.respAge != 0 {
+				require.Equal(t, tt.respAge, resp.Actor.Age)
+			}
 		})
 	}
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -12,9 +12,9 @@ type ActorCreator struct {
 	mock.Mock
 }
 
-// CreateActor provides a mock function with given fields: actor
-func (_m *ActorCreator) CreateActor(actor *entity.NewActor) (int, error) {
-	ret := _m.Called(actor)
+// CreateActor provides a mock function with given fields: actor, audit
+func (_m *ActorCreator) CreateActor(actor *entity.NewActor, audit entity.AuditInfo) (int, error) {
+	ret := _m.Called(actor, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateActor")
@@ -22,17 +22,17 @@ func (_m *ActorCreator) CreateActor(actor *entity.NewActor) (int, error) {
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*entity.NewActor) (int, error)); ok {
-		return rf(actor)
+	if rf, ok := ret.Get(0).(func(*entity.NewActor, entity.AuditInfo) (int, error)); ok {
+		return rf(actor, audit)
 	}
-	if rf, ok := ret.Get(0).(func(*entity.NewActor) int); ok {
-		r0 = rf(actor)
+	if rf, ok := ret.Get(0).(func(*entity.NewActor, entity.AuditInfo) int); ok {
+		r0 = rf(actor, audit)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(*entity.NewActor) error); ok {
-		r1 = rf(actor)
+	if rf, ok := ret.Get(1).(func(*entity.NewActor, entity.AuditInfo) error); ok {
+		r1 = rf(actor, audit)
 	} else {
 		r1 = ret.Error(1)
 	}
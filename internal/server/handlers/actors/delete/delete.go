@@ -1,9 +1,15 @@
 package delete
 
 import (
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/prefer"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,10 +17,14 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorDeleter
 type ActorDeleter interface {
-	DeleteActor(id int) error
+	GetActorById(id int, langs []string) (*entity.Actor, error)
+	// DeleteActor only deletes actor of non-zero version if it is still current
+	DeleteActor(id, version int, audit entity.AuditInfo) error
 }
 
-func New(log *slog.Logger, actorDeleter ActorDeleter) http.HandlerFunc {
+// New deletes actor, If-Match header is checked against ETag of the actor when present.
+// Missing actor is 404, unless client prefers idempotent delete, which responds 204 either way.
+func New(log *slog.Logger, actorDeleter ActorDeleter, requireIfMatch bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actors.delete.New"
 
@@ -35,11 +45,62 @@ func New(log *slog.Logger, actorDeleter ActorDeleter) http.HandlerFunc {
 			return
 		}
 
-		err = actorDeleter.DeleteActor(id)
+		// Zero version deletes actor regardless of its version
+		version := 0
+		ifMatch := r.Header.Get("If-Match")
+		if ifMatch == "" && requireIfMatch {
+			log.Error("Missing If-Match header")
+			w.WriteHeader(http.StatusPreconditionRequired)
+			render.JSON(w, r, resp.Error("If-Match header is required"))
+			return
+		}
+		if ifMatch != "" {
+			current, err := actorDeleter.GetActorById(id, nil)
+			if err != nil && !errors.Is(err, storage.ErrActorNotFound) {
+				log.Error("Failed to get actor", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to delete actor")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+			// Gone actor has no current ETag to match
+			if err != nil || !etag.Match(ifMatch, current.Version) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Actor was modified"))
+				return
+			}
+			version = current.Version
+		}
+
+		// Idempotent delete succeeds for missing actor, unless its version was expected
+		idempotent := prefer.Has(r, prefer.Idempotent)
+
+		err = actorDeleter.DeleteActor(id, version, audit.FromRequest(r))
+		if errors.Is(err, storage.ErrActorNotFound) && version == 0 {
+			if !idempotent {
+				status, errResp := resp.StorageError(err, "Failed to delete actor")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+			err = nil
+		}
 		if err != nil {
+			if errors.Is(err, storage.ErrVersionConflict) || errors.Is(err, storage.ErrActorNotFound) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Actor was modified"))
+				return
+			}
 			log.Error("Failed to delete actor", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to delete actor"))
+			status, errResp := resp.StorageError(err, "Failed to delete actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if idempotent {
+			w.Header().Set(prefer.AppliedHeader, prefer.Idempotent)
+			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
@@ -1,11 +1,15 @@
 package delete_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	actorsDelete "github.com/rmntim/movielab/internal/server/handlers/actors/delete"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -21,12 +25,88 @@ func TestActorsDelete(t *testing.T) {
 		respCode  int
 		respError string
 		mockError error
+		ifMatch   string
+		// requireIfMatch is passed to handler
+		requireIfMatch bool
+		getError       error
+		// version is expected by DeleteActor, current version of the actor is 3
+		version int
+		// prefer is sent in Prefer header
+		prefer string
 	}{
 		{
 			name:     "Success",
 			id:       "1",
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "Success with If-Match",
+			id:       "1",
+			ifMatch:  `"3"`,
+			version:  3,
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Missing actor",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:     "Idempotent delete",
+			id:       "1",
+			prefer:   "idempotent",
+			respCode: http.StatusNoContent,
+		},
+		{
+			name:      "Idempotent delete of missing actor",
+			id:        "1",
+			prefer:    "return=minimal, idempotent",
+			respCode:  http.StatusNoContent,
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "Idempotent delete of actor deleted meanwhile",
+			id:        "1",
+			ifMatch:   `"3"`,
+			version:   3,
+			prefer:    "idempotent",
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "If-Match mismatch",
+			id:        "1",
+			ifMatch:   `"2"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+		},
+		{
+			name:      "If-Match of missing actor",
+			id:        "1",
+			ifMatch:   `"3"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+			getError:  storage.ErrActorNotFound,
+		},
+		{
+			name:      "Concurrent update",
+			id:        "1",
+			ifMatch:   "*",
+			version:   3,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+			mockError: storage.ErrVersionConflict,
+		},
+		{
+			name:           "If-Match required",
+			id:             "1",
+			requireIfMatch: true,
+			respCode:       http.StatusPreconditionRequired,
+			respError:      "If-Match header is required",
+		},
 		{
 			name:      "Bad id",
 			id:        "a",
@@ -56,14 +136,20 @@ func TestActorsDelete(t *testing.T) {
 
 			actorsDeleterMock := mocks.NewActorDeleter(t)
 
+			if tt.ifMatch != "" {
+				actorsDeleterMock.
+					On("GetActorById", 1, []string(nil)).
+					Return(&entity.Actor{Version: 3}, tt.getError).
+					Once()
+			}
 			if tt.respError == "" || tt.mockError != nil {
 				actorsDeleterMock.
-					On("DeleteActor", mock.AnythingOfType("int")).
+					On("DeleteActor", mock.AnythingOfType("int"), tt.version, entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
 					Return(tt.mockError).
 					Once()
 			}
 
-			handler := actorsDelete.New(slogdiscard.NewDiscardLogger(), actorsDeleterMock)
+			handler := actorsDelete.New(slogdiscard.NewDiscardLogger(), actorsDeleterMock, tt.requireIfMatch)
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("DELETE /{id}", handler)
@@ -76,11 +162,27 @@ func TestActorsDelete(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			if tt.prefer != "" {
+				req.Header.Set("Prefer", tt.prefer)
+			}
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.respCode == http.StatusNoContent {
+				require.Equal(t, "idempotent", rr.Header().Get("Preference-Applied"))
+				require.Empty(t, rr.Body.String())
+				return
+			}
+			var errResp resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+			require.Equal(t, tt.respError, errResp.Error)
 		})
 	}
 }
@@ -2,24 +2,27 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	entity "github.com/rmntim/movielab/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorDeleter is an autogenerated mock type for the ActorDeleter type
 type ActorDeleter struct {
 	mock.Mock
 }
 
-// DeleteActor provides a mock function with given fields: id
-func (_m *ActorDeleter) DeleteActor(id int) error {
-	ret := _m.Called(id)
+// DeleteActor provides a mock function with given fields: id, version, audit
+func (_m *ActorDeleter) DeleteActor(id int, version int, audit entity.AuditInfo) error {
+	ret := _m.Called(id, version, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteActor")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, int, entity.AuditInfo) error); ok {
+		r0 = rf(id, version, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,6 +30,36 @@ func (_m *ActorDeleter) DeleteActor(id int) error {
 	return r0
 }
 
+// GetActorById provides a mock function with given fields: id, langs
+func (_m *ActorDeleter) GetActorById(id int, langs []string) (*entity.Actor, error) {
+	ret := _m.Called(id, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActorById")
+	}
+
+	var r0 *entity.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Actor, error)); ok {
+		return rf(id, langs)
+	}
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Actor); ok {
+		r0 = rf(id, langs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Actor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewActorDeleter creates a new instance of ActorDeleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewActorDeleter(t interface {
@@ -0,0 +1,93 @@
+package duplicates
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const defaultSimilarity = 0.6
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=DuplicateActorsGetter
+type DuplicateActorsGetter interface {
+	GetDuplicateActors(minSimilarity float64, limit, offset int) ([]entity.DuplicateCandidate, error)
+}
+
+type Response struct {
+	resp.Response
+	Duplicates []entity.DuplicateCandidate `json:"duplicates"`
+}
+
+func New(log *slog.Logger, duplicateActorsGetter DuplicateActorsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.duplicates.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		var (
+			limit      = 10
+			offset     = 0
+			similarity = defaultSimilarity
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+		querySimilarity := r.URL.Query().Get("similarity")
+		if querySimilarity != "" {
+			similarity, err = strconv.ParseFloat(querySimilarity, 64)
+			if err != nil || similarity < 0 || similarity > 1 {
+				log.Error("Invalid similarity", slog.String("similarity", querySimilarity))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("similarity must be between 0 and 1"))
+				return
+			}
+		}
+
+		duplicates, err := duplicateActorsGetter.GetDuplicateActors(similarity, limit, offset)
+		if err != nil {
+			log.Error("Failed to get duplicates", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get duplicates")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if duplicates == nil {
+			duplicates = []entity.DuplicateCandidate{}
+		}
+
+		render.JSON(w, r, Response{
+			Response:   resp.Ok(),
+			Duplicates: duplicates,
+		})
+	}
+}
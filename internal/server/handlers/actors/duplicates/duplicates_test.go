@@ -0,0 +1,128 @@
+package duplicates_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/duplicates"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/duplicates/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDuplicateActors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		role       string
+		similarity float64
+		limit      int
+		offset     int
+		mockBody   []entity.DuplicateCandidate
+		respBody   []entity.DuplicateCandidate
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success",
+			similarity: 0.6,
+			limit:      10,
+			mockBody: []entity.DuplicateCandidate{{
+				Actor:         entity.ActorSummary{ID: 1, Name: "Keanu Reeves"},
+				Duplicate:     entity.ActorSummary{ID: 2, Name: "Keanu Reeves"},
+				Similarity:    1,
+				SameBirthDate: true,
+			}},
+			respBody: []entity.DuplicateCandidate{{
+				Actor:         entity.ActorSummary{ID: 1, Name: "Keanu Reeves"},
+				Duplicate:     entity.ActorSummary{ID: 2, Name: "Keanu Reeves"},
+				Similarity:    1,
+				SameBirthDate: true,
+			}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:       "No duplicates",
+			query:      "similarity=0.9&limit=5&offset=5",
+			similarity: 0.9,
+			limit:      5,
+			offset:     5,
+			respBody:   []entity.DuplicateCandidate{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad similarity",
+			query:     "similarity=2",
+			respCode:  http.StatusBadRequest,
+			respError: "similarity must be between 0 and 1",
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:       "GetDuplicateActors error",
+			similarity: 0.6,
+			limit:      10,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get duplicates",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			duplicateActorsGetterMock := mocks.NewDuplicateActorsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				duplicateActorsGetterMock.
+					On("GetDuplicateActors", tt.similarity, tt.limit, tt.offset).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := duplicates.New(slogdiscard.NewDiscardLogger(), duplicateActorsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/?%s", tt.query), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp duplicates.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Duplicates)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -4,17 +4,23 @@ import (
 	"errors"
 	"github.com/go-chi/render"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/fieldset"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"github.com/rmntim/movielab/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorByIdGetter
 type ActorByIdGetter interface {
-	GetActorById(id int) (*entity.Actor, error)
+	GetActorById(id int, langs []string) (*entity.Actor, error)
+	GetActorRedirect(id int) (int, error)
+	EmbedMovies(actors []entity.Actor, langs []string) error
 }
 
 type Response struct {
@@ -22,6 +28,7 @@ type Response struct {
 	Actor *entity.Actor `json:"actor"`
 }
 
+// New returns actor, its movies are embedded with `include=movies`
 func New(log *slog.Logger, actorByIdGetter ActorByIdGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actors.get.New"
@@ -36,22 +43,72 @@ func New(log *slog.Logger, actorByIdGetter ActorByIdGetter) http.HandlerFunc {
 			return
 		}
 
-		actor, err := actorByIdGetter.GetActorById(id)
+		include, err := fieldset.Include(r.URL.Query(), "movies")
+		if err != nil {
+			log.Error("Failed to parse include", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse include"))
+			return
+		}
+
+		// Translated fields depend on requested language
+		w.Header().Add("Vary", "Accept-Language")
+
+		actor, err := actorByIdGetter.GetActorById(id, locale.FromRequest(r))
 		if err != nil {
 			if errors.Is(err, storage.ErrActorNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("Actor not found"))
+				// Merged duplicates permanently redirect to the surviving actor
+				if actorID, err := actorByIdGetter.GetActorRedirect(id); err == nil {
+					location := strings.TrimSuffix(r.URL.Path, r.PathValue("id")) + strconv.Itoa(actorID)
+					if r.URL.RawQuery != "" {
+						location += "?" + r.URL.RawQuery
+					}
+					http.Redirect(w, r, location, http.StatusPermanentRedirect)
+					return
+				} else if !errors.Is(err, storage.ErrActorNotFound) {
+					log.Error("Failed to get actor redirect", sl.Err(err))
+				}
+				status, errResp := resp.StorageError(storage.ErrActorNotFound, "Failed to get actor")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
 				return
 			}
 			log.Error("Failed to get actor", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get actor"))
+			status, errResp := resp.StorageError(err, "Failed to get actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
-		render.JSON(w, r, Response{
+		if include["movies"] {
+			actors := []entity.Actor{*actor}
+			if err := actorByIdGetter.EmbedMovies(actors, locale.FromRequest(r)); err != nil {
+				log.Error("Failed to get movies", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to get actor")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+			actor = &actors[0]
+		}
+
+		response := Response{
 			Response: resp.Ok(),
 			Actor:    actor,
-		})
+		}
+
+		tag, err := etag.Tag(actor.Version, response)
+		if err != nil {
+			log.Error("Failed to tag actor", sl.Err(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("Failed to get actor"))
+			return
+		}
+		if etag.NotModified(w, r, tag, actor.LastModified()) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		render.JSON(w, r, response)
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/get"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/get/mocks"
@@ -14,16 +16,23 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestActorsGet(t *testing.T) {
 	tests := []struct {
 		name      string
 		id        string
+		query     string
 		respBody  *entity.Actor
 		respCode  int
 		respError string
 		mockError error
+		// redirectID is id of actor that merged requested one, zero if there is no redirect
+		redirectID int
+		location   string
+		// notModified sends tag of the returned actor as If-None-Match
+		notModified bool
 	}{
 		{
 			name:     "Success",
@@ -31,6 +40,13 @@ func TestActorsGet(t *testing.T) {
 			respBody: &entity.Actor{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:        "Not modified",
+			id:          "1",
+			respBody:    &entity.Actor{},
+			respCode:    http.StatusNotModified,
+			notModified: true,
+		},
 		{
 			name:      "Bad id",
 			id:        "a",
@@ -51,6 +67,23 @@ func TestActorsGet(t *testing.T) {
 			respError: "Actor not found",
 			mockError: storage.ErrActorNotFound,
 		},
+		{
+			name:       "Merged actor redirect",
+			id:         "1",
+			respCode:   http.StatusPermanentRedirect,
+			mockError:  storage.ErrActorNotFound,
+			redirectID: 2,
+			location:   "/2",
+		},
+		{
+			name:       "Merged actor redirect keeps query",
+			id:         "1",
+			query:      "include=movies",
+			respCode:   http.StatusPermanentRedirect,
+			mockError:  storage.ErrActorNotFound,
+			redirectID: 2,
+			location:   "/2?include=movies",
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,23 +96,146 @@ func TestActorsGet(t *testing.T) {
 
 			if tt.respError == "" || tt.mockError != nil {
 				actorsByIdGetterMock.
-					On("GetActorById", mock.AnythingOfType("int")).
+					On("GetActorById", mock.AnythingOfType("int"), []string{"fr-ca", "fr"}).
 					Return(tt.respBody, tt.mockError).
 					Once()
 			}
+			if errors.Is(tt.mockError, storage.ErrActorNotFound) {
+				redirectErr := storage.ErrActorNotFound
+				if tt.redirectID != 0 {
+					redirectErr = nil
+				}
+				actorsByIdGetterMock.
+					On("GetActorRedirect", 1).
+					Return(tt.redirectID, redirectErr).
+					Once()
+			}
 
 			handler := get.New(slogdiscard.NewDiscardLogger(), actorsByIdGetterMock)
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("/{id}", handler)
 
-			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s", tt.id), nil)
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s?%s", tt.id, tt.query), nil)
 			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "fr-CA, en;q=0")
+			if tt.notModified {
+				tag, err := etag.Tag(0, get.Response{Response: resp.Ok(), Actor: tt.respBody})
+				require.NoError(t, err)
+				req.Header.Set("If-None-Match", tag)
+			}
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.location != "" {
+				require.Equal(t, tt.location, rr.Header().Get("Location"))
+				return
+			}
+			if tt.notModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
+
+			var resp get.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Actor)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
+
+func TestActorsGetInclude(t *testing.T) {
+	movies := []entity.Movie{{ID: 2, NewMovie: entity.NewMovie{Title: "Heat"}, UpdatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	// rendered are movies as they are sent, without update time
+	rendered := []entity.Movie{{ID: 2, NewMovie: entity.NewMovie{Title: "Heat"}}}
+
+	tests := []struct {
+		name       string
+		include    string
+		respBody   *entity.Actor
+		respCode   int
+		respError  string
+		embedError error
+		// noMovies makes embedding find no movies of the actor
+		noMovies     bool
+		lastModified string
+	}{
+		{
+			// Movie changed after the actor, so the cached copy is stale
+			name:         "Include movies",
+			include:      "movies",
+			respBody:     &entity.Actor{ID: 1, MovieIDs: []int32{2}, Movies: &rendered},
+			respCode:     http.StatusOK,
+			lastModified: "Tue, 02 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:         "Include movies of actor without movies",
+			include:      "movies",
+			noMovies:     true,
+			respCode:     http.StatusNotModified,
+			lastModified: "Mon, 01 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:      "Unknown include",
+			include:   "awards",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse include",
+		},
+		{
+			name:       "EmbedMovies error",
+			include:    "movies",
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get actor",
+			embedError: errors.New("failed to get movies"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorsByIdGetterMock := mocks.NewActorByIdGetter(t)
+			actorsByIdGetterMock.
+				On("GetActorById", 1, []string{"en"}).
+				Return(&entity.Actor{ID: 1, MovieIDs: []int32{2}, Version: 3, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil).
+				Maybe()
+			actorsByIdGetterMock.
+				On("EmbedMovies", mock.AnythingOfType("[]entity.Actor"), []string{"en"}).
+				Run(func(args mock.Arguments) {
+					embedded := movies
+					if tt.noMovies {
+						embedded = []entity.Movie{}
+					}
+					args.Get(0).([]entity.Actor)[0].Movies = &embedded
+				}).
+				Return(tt.embedError).
+				Maybe()
+
+			handler := get.New(slogdiscard.NewDiscardLogger(), actorsByIdGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}", handler)
+
+			req, err := http.NewRequest(http.MethodGet, "/1?include="+tt.include, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+			req.Header.Set("If-Modified-Since", "Mon, 01 Jan 2024 00:00:00 GMT")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			require.Equal(t, tt.lastModified, rr.Header().Get("Last-Modified"))
+			if rr.Code == http.StatusNotModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
+
 			var resp get.Response
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
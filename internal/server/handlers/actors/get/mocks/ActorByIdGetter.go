@@ -13,9 +13,27 @@ type ActorByIdGetter struct {
 	mock.Mock
 }
 
-// GetActorById provides a mock function with given fields: id
-func (_m *ActorByIdGetter) GetActorById(id int) (*entity.Actor, error) {
-	ret := _m.Called(id)
+// EmbedMovies provides a mock function with given fields: actors, langs
+func (_m *ActorByIdGetter) EmbedMovies(actors []entity.Actor, langs []string) error {
+	ret := _m.Called(actors, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmbedMovies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]entity.Actor, []string) error); ok {
+		r0 = rf(actors, langs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActorById provides a mock function with given fields: id, langs
+func (_m *ActorByIdGetter) GetActorById(id int, langs []string) (*entity.Actor, error) {
+	ret := _m.Called(id, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActorById")
@@ -23,17 +41,45 @@ func (_m *ActorByIdGetter) GetActorById(id int) (*entity.Actor, error) {
 
 	var r0 *entity.Actor
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*entity.Actor, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Actor, error)); ok {
+		return rf(id, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int) *entity.Actor); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Actor); ok {
+		r0 = rf(id, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Actor)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActorRedirect provides a mock function with given fields: id
+func (_m *ActorByIdGetter) GetActorRedirect(id int) (int, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActorRedirect")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
 	if rf, ok := ret.Get(1).(func(int) error); ok {
 		r1 = rf(id)
 	} else {
@@ -0,0 +1,114 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	headshotDelete "github.com/rmntim/movielab/internal/server/handlers/actors/headshot/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/headshot/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHeadshotDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		role        string
+		respCode    int
+		respError   string
+		setterError error
+		deleteError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			role:     "admin",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Not admin",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:        "Actor not found",
+			id:          "1",
+			role:        "admin",
+			respCode:    http.StatusNotFound,
+			respError:   "Actor not found",
+			setterError: storage.ErrActorNotFound,
+		},
+		{
+			name:        "SetActorHeadshot error",
+			id:          "1",
+			role:        "admin",
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to delete headshot",
+			setterError: errors.New("unexpected error"),
+		},
+		{
+			name:        "Delete error",
+			id:          "1",
+			role:        "admin",
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to delete headshot",
+			deleteError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			headshotSetterMock := mocks.NewHeadshotSetter(t)
+			imageDeleterMock := mocks.NewImageDeleter(t)
+
+			if tt.respError == "" || tt.setterError != nil || tt.deleteError != nil {
+				headshotSetterMock.
+					On("SetActorHeadshot", 1, (*entity.Image)(nil)).
+					Return(nil, tt.setterError).
+					Once()
+			}
+			if tt.respError == "" || tt.deleteError != nil {
+				imageDeleterMock.
+					On("Delete", "actors/1/headshot").
+					Return(tt.deleteError).
+					Once()
+			}
+
+			handler := headshotDelete.New(slogdiscard.NewDiscardLogger(), headshotSetterMock, imageDeleterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/headshot", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s/headshot", tt.id), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
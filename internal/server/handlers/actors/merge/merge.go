@@ -0,0 +1,95 @@
+package merge
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorMerger
+type ActorMerger interface {
+	MergeActors(id, duplicateID int, audit entity.AuditInfo) error
+	GetActorById(id int, langs []string) (*entity.Actor, error)
+}
+
+type Response struct {
+	resp.Response
+	Actor *entity.Actor `json:"actor"`
+}
+
+func New(log *slog.Logger, actorMerger ActorMerger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.merge.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		var merge entity.ActorMerge
+		if err := render.DecodeJSON(r.Body, &merge); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(merge); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		if merge.DuplicateID == id {
+			log.Error("Actor merged into itself", slog.Int("id", id))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Cannot merge actor into itself"))
+			return
+		}
+
+		if err := actorMerger.MergeActors(id, merge.DuplicateID, audit.FromRequest(r)); err != nil {
+			log.Error("Failed to merge actors", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to merge actors")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		actor, err := actorMerger.GetActorById(id, nil)
+		if err != nil {
+			log.Error("Failed to get actor", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Actor:    actor,
+		})
+	}
+}
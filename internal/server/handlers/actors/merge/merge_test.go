@@ -0,0 +1,147 @@
+package merge_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/merge"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/merge/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestActorMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		body      string
+		role      string
+		respBody  *entity.Actor
+		respCode  int
+		respError string
+		mockError error
+		getError  error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			body:     `{"duplicate_id": 2}`,
+			respBody: &entity.Actor{ID: 1, NewActor: entity.NewActor{Name: "Keanu Reeves", Aliases: []string{"Keanu Reaves"}}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			body:      `{"duplicate_id": 2}`,
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      `{"duplicate_id": 2}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad body",
+			id:        "1",
+			body:      `{`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid request",
+		},
+		{
+			name:      "Missing duplicate",
+			id:        "1",
+			body:      `{}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field DuplicateID is required",
+		},
+		{
+			name:      "Merge into itself",
+			id:        "1",
+			body:      `{"duplicate_id": 1}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Cannot merge actor into itself",
+		},
+		{
+			name:      "Actor not found",
+			id:        "1",
+			body:      `{"duplicate_id": 2}`,
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "MergeActors error",
+			id:        "1",
+			body:      `{"duplicate_id": 2}`,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to merge actors",
+			mockError: errors.New("unexpected error"),
+		},
+		{
+			name:      "GetActorById error",
+			id:        "1",
+			body:      `{"duplicate_id": 2}`,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get actor",
+			getError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorMergerMock := mocks.NewActorMerger(t)
+
+			if tt.respError == "" || tt.mockError != nil || tt.getError != nil {
+				actorMergerMock.
+					On("MergeActors", 1, 2, entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
+					Return(tt.mockError).
+					Once()
+			}
+			if tt.respError == "" || tt.getError != nil {
+				actorMergerMock.
+					On("GetActorById", 1, []string(nil)).
+					Return(tt.respBody, tt.getError).
+					Once()
+			}
+
+			handler := merge.New(slogdiscard.NewDiscardLogger(), actorMergerMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/merge", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/merge", tt.id), strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp merge.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Actor)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package path
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultMaxDepth = 6
+	maxMaxDepth     = 10
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorPathFinder
+type ActorPathFinder interface {
+	FindActorPath(fromID, toID, maxDepth int) (*entity.ActorPath, error)
+}
+
+type Response struct {
+	resp.Response
+	Path *entity.ActorPath `json:"path,omitempty"`
+}
+
+func New(log *slog.Logger, actorPathFinder ActorPathFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.path.New"
+
+		log := log.With(slog.String("op", op))
+
+		fromID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+		toID, err := strconv.Atoi(r.PathValue("to_id"))
+		if err != nil {
+			log.Error("Failed to parse target id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse target id"))
+			return
+		}
+
+		// Depth is capped so that searches on large catalogs stay bounded
+		maxDepth := defaultMaxDepth
+		queryMaxDepth := r.URL.Query().Get("max_depth")
+		if queryMaxDepth != "" {
+			maxDepth, err = strconv.Atoi(queryMaxDepth)
+			if err != nil || maxDepth < 1 || maxDepth > maxMaxDepth {
+				log.Error("Invalid max_depth", slog.String("max_depth", queryMaxDepth))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("max_depth must be between 1 and 10"))
+				return
+			}
+		}
+
+		path, err := actorPathFinder.FindActorPath(fromID, toID, maxDepth)
+		if err != nil {
+			log.Error("Failed to find path", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to find path")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Path:     path,
+		})
+	}
+}
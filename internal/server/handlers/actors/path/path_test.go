@@ -0,0 +1,143 @@
+package path_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/path"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/path/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		query     string
+		maxDepth  int
+		respBody  *entity.ActorPath
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			from:     "1",
+			to:       "3",
+			maxDepth: 6,
+			respBody: &entity.ActorPath{
+				Degrees: 2,
+				Actors:  []entity.ActorSummary{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}},
+				Movies:  []entity.MovieSummary{{ID: 1, Title: "X"}, {ID: 2, Title: "Y"}},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Custom depth",
+			from:     "1",
+			to:       "3",
+			query:    "max_depth=2",
+			maxDepth: 2,
+			respBody: &entity.ActorPath{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			from:      "a",
+			to:        "3",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad target id",
+			from:      "1",
+			to:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse target id",
+		},
+		{
+			name:      "Depth too large",
+			from:      "1",
+			to:        "3",
+			query:     "max_depth=11",
+			respCode:  http.StatusBadRequest,
+			respError: "max_depth must be between 1 and 10",
+		},
+		{
+			name:      "Bad depth",
+			from:      "1",
+			to:        "3",
+			query:     "max_depth=a",
+			respCode:  http.StatusBadRequest,
+			respError: "max_depth must be between 1 and 10",
+		},
+		{
+			name:      "Actor not found",
+			from:      "1",
+			to:        "3",
+			maxDepth:  6,
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "Path not found",
+			from:      "1",
+			to:        "3",
+			maxDepth:  6,
+			respCode:  http.StatusNotFound,
+			respError: "Path not found",
+			mockError: storage.ErrPathNotFound,
+		},
+		{
+			name:      "FindActorPath error",
+			from:      "1",
+			to:        "3",
+			maxDepth:  6,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to find path",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorPathFinderMock := mocks.NewActorPathFinder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				actorPathFinderMock.
+					On("FindActorPath", 1, 3, tt.maxDepth).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := path.New(slogdiscard.NewDiscardLogger(), actorPathFinderMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}/path/{to_id}", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/path/%s?%s", tt.from, tt.to, tt.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp path.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Path)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
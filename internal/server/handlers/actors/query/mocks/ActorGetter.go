@@ -12,9 +12,27 @@ type ActorGetter struct {
 	mock.Mock
 }
 
-// GetActors provides a mock function with given fields: limit, offset
-func (_m *ActorGetter) GetActors(limit int, offset int) ([]entity.Actor, error) {
-	ret := _m.Called(limit, offset)
+// EmbedMovies provides a mock function with given fields: actors, langs
+func (_m *ActorGetter) EmbedMovies(actors []entity.Actor, langs []string) error {
+	ret := _m.Called(actors, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmbedMovies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]entity.Actor, []string) error); ok {
+		r0 = rf(actors, langs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActors provides a mock function with given fields: limit, offset, filter, langs
+func (_m *ActorGetter) GetActors(limit int, offset int, filter *entity.ActorFilter, langs []string) ([]entity.Actor, error) {
+	ret := _m.Called(limit, offset, filter, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActors")
@@ -22,19 +40,19 @@ func (_m *ActorGetter) GetActors(limit int, offset int) ([]entity.Actor, error)
 
 	var r0 []entity.Actor
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]entity.Actor, error)); ok {
-		return rf(limit, offset)
+	if rf, ok := ret.Get(0).(func(int, int, *entity.ActorFilter, []string) ([]entity.Actor, error)); ok {
+		return rf(limit, offset, filter, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []entity.Actor); ok {
-		r0 = rf(limit, offset)
+	if rf, ok := ret.Get(0).(func(int, int, *entity.ActorFilter, []string) []entity.Actor); ok {
+		r0 = rf(limit, offset, filter, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Actor)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(limit, offset)
+	if rf, ok := ret.Get(1).(func(int, int, *entity.ActorFilter, []string) error); ok {
+		r1 = rf(limit, offset, filter, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,18 +1,28 @@
 package query
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/fieldset"
+	apiFilter "github.com/rmntim/movielab/internal/lib/api/filter"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorGetter
 type ActorGetter interface {
-	GetActors(limit, offset int) ([]entity.Actor, error)
+	GetActors(limit, offset int, filter *entity.ActorFilter, langs []string) ([]entity.Actor, error)
+	EmbedMovies(actors []entity.Actor, langs []string) error
 }
 
 type Response struct {
@@ -20,6 +30,12 @@ type Response struct {
 	Actors []entity.Actor `json:"actors"`
 }
 
+// SparseResponse has actors trimmed to fields requested with `fields`
+type SparseResponse struct {
+	resp.Response
+	Actors []map[string]json.RawMessage `json:"actors"`
+}
+
 func New(log *slog.Logger, actorGetter ActorGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actors.query.New"
@@ -53,17 +69,133 @@ func New(log *slog.Logger, actorGetter ActorGetter) http.HandlerFunc {
 			}
 		}
 
-		actors, err := actorGetter.GetActors(limit, offset)
+		// Translated fields depend on requested language
+		w.Header().Add("Vary", "Accept-Language")
+
+		tags, ok := entity.NormalizeTags(r.URL.Query()["tag"])
+		if !ok {
+			log.Error("Invalid tag", slog.Any("tag", r.URL.Query()["tag"]))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse tag"))
+			return
+		}
+
+		awards, param, err := apiFilter.Awards(r.URL.Query())
+		if err != nil {
+			log.Error("Failed to parse "+param, sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse "+param))
+			return
+		}
+
+		includeDeleted, err := apiFilter.IncludeDeleted(r.URL.Query())
+		if err != nil {
+			log.Error("Failed to parse include_deleted", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse include_deleted"))
+			return
+		}
+		if includeDeleted && r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		include, err := fieldset.Include(r.URL.Query(), "movies")
+		if err != nil {
+			log.Error("Failed to parse include", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse include"))
+			return
+		}
+
+		fields, err := fieldset.Fields(r.URL.Query(), entity.Actor{})
+		if err != nil {
+			log.Error("Failed to parse fields", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse fields"))
+			return
+		}
+
+		filter := &entity.ActorFilter{
+			Name:           r.URL.Query().Get("name"),
+			Tags:           tags,
+			AwardFilter:    awards,
+			IncludeDeleted: includeDeleted,
+		}
+		if err := validate.Struct(filter); err != nil {
+			log.Error("Invalid filter", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		// Translated fields depend on requested language
+		w.Header().Add("Vary", "Accept-Language")
+
+		actors, err := actorGetter.GetActors(limit, offset, filter, locale.FromRequest(r))
 		if err != nil {
 			log.Error("Failed to get actors", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get actors"))
+			status, errResp := resp.StorageError(err, "Failed to get actors")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
-		render.JSON(w, r, Response{
+		if include["movies"] {
+			if err := actorGetter.EmbedMovies(actors, locale.FromRequest(r)); err != nil {
+				log.Error("Failed to get movies", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to get actors")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+		}
+
+		var response any = Response{
 			Response: resp.Ok(),
 			Actors:   actors,
-		})
+		}
+		if fields != nil {
+			// Included movies are kept in sparse fieldset
+			if include["movies"] {
+				fields = append(fields, "movies")
+			}
+			selected, err := fieldset.Select(actors, fields)
+			if err != nil {
+				log.Error("Failed to select fields", sl.Err(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("Failed to get actors"))
+				return
+			}
+			response = SparseResponse{
+				Response: resp.Ok(),
+				Actors:   selected,
+			}
+		}
+
+		tag, err := etag.Digest(response)
+		if err != nil {
+			log.Error("Failed to tag actors", sl.Err(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("Failed to get actors"))
+			return
+		}
+		// List was last modified when the latest of its actors was
+		var modified time.Time
+		for _, actor := range actors {
+			if last := actor.LastModified(); last.After(modified) {
+				modified = last
+			}
+		}
+		if etag.NotModified(w, r, tag, modified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		render.JSON(w, r, response)
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/query"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/query/mocks"
@@ -13,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestActorQuery(t *testing.T) {
@@ -20,10 +23,21 @@ func TestActorQuery(t *testing.T) {
 		name      string
 		limit     string
 		offset    string
-		respBody  []entity.Actor
-		respCode  int
-		respError string
-		mockError error
+		actorName string
+		tags      string
+		mockTags  []string
+		awards    string
+		role      string
+		// includeDeleted is passed as include_deleted parameter when set
+		includeDeleted string
+		// mockAwards is expected award filter, empty by default
+		mockAwards entity.AwardFilter
+		respBody   []entity.Actor
+		respCode   int
+		respError  string
+		mockError  error
+		// notModified sends tag of the returned list as If-None-Match
+		notModified bool
 	}{
 		{
 			name:     "Success",
@@ -32,6 +46,80 @@ func TestActorQuery(t *testing.T) {
 			respBody: []entity.Actor{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:        "Not modified",
+			limit:       "10",
+			offset:      "0",
+			respBody:    []entity.Actor{},
+			respCode:    http.StatusNotModified,
+			notModified: true,
+		},
+		{
+			name:      "Success by name",
+			limit:     "10",
+			offset:    "0",
+			actorName: "Test",
+			respBody:  []entity.Actor{},
+			respCode:  http.StatusOK,
+		},
+		{
+			name:     "Success by tags",
+			limit:    "10",
+			offset:   "0",
+			tags:     "tag=Oscar-Winner&tag=cult++classic",
+			mockTags: []string{"oscar-winner", "cult classic"},
+			respBody: []entity.Actor{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:       "Success by awards",
+			limit:      "10",
+			offset:     "0",
+			awards:     "award_category=2&award_won=1&min_nominations=3",
+			mockAwards: entity.AwardFilter{AwardCategoryID: 2, AwardWon: true, MinNominations: 3},
+			respBody:   []entity.Actor{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:           "Success including deleted",
+			limit:          "10",
+			offset:         "0",
+			includeDeleted: "true",
+			role:           "admin",
+			respBody:       []entity.Actor{},
+			respCode:       http.StatusOK,
+		},
+		{
+			name:           "Include deleted unauthorized",
+			includeDeleted: "1",
+			role:           "user",
+			respCode:       http.StatusUnauthorized,
+			respError:      "Insufficient permissions",
+		},
+		{
+			name:           "Bad include deleted",
+			includeDeleted: "maybe",
+			respCode:       http.StatusBadRequest,
+			respError:      "Failed to parse include_deleted",
+		},
+		{
+			name:      "Bad award filter",
+			awards:    "min_wins=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse min_wins",
+		},
+		{
+			name:      "Negative nominations",
+			awards:    "min_nominations=-1",
+			respCode:  http.StatusBadRequest,
+			respError: "field MinNominations is invalid",
+		},
+		{
+			name:      "Bad tag",
+			tags:      "tag=+",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse tag",
+		},
 		{
 			name:      "Bad limit",
 			limit:     "a",
@@ -63,21 +151,34 @@ func TestActorQuery(t *testing.T) {
 
 			if tt.respError == "" || tt.mockError != nil {
 				actorGetterMock.
-					On("GetActors", mock.AnythingOfType("int"), mock.AnythingOfType("int")).
+					On("GetActors", mock.AnythingOfType("int"), mock.AnythingOfType("int"), &entity.ActorFilter{Name: tt.actorName, Tags: tt.mockTags, AwardFilter: tt.mockAwards, IncludeDeleted: tt.includeDeleted != ""}, []string{"fr-ca", "fr"}).
 					Return(tt.respBody, tt.mockError)
 			}
 
 			handler := query.New(slogdiscard.NewDiscardLogger(), actorGetterMock)
 
-			req, err := http.NewRequest(http.MethodGet,
-				fmt.Sprintf("/?limit=%s&offset=%s", tt.limit, tt.offset),
-				nil)
+			url := fmt.Sprintf("/?limit=%s&offset=%s&name=%s&%s&%s", tt.limit, tt.offset, tt.actorName, tt.tags, tt.awards)
+			if tt.includeDeleted != "" {
+				url += "&include_deleted=" + tt.includeDeleted
+			}
+			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+			req.Header.Set("Accept-Language", "fr-CA, en;q=0")
+			if tt.notModified {
+				tag, err := etag.Digest(query.Response{Response: resp.Ok(), Actors: tt.respBody})
+				require.NoError(t, err)
+				req.Header.Set("If-None-Match", tag)
+			}
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.notModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
 			var resp query.Response
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
@@ -86,3 +187,167 @@ func TestActorQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestActorQueryInclude(t *testing.T) {
+	actors := []entity.Actor{{ID: 1, NewActor: entity.NewActor{Name: "Al Pacino", Sex: "male"}, Age: 84, MovieIDs: []int32{2}}}
+	movies := []entity.Movie{{ID: 2, NewMovie: entity.NewMovie{Title: "Heat", Rating: 8}}}
+	moviesJSON := `[{"id":2,"title":"Heat","release_date":"0001-01-01T00:00:00Z","rating":8,"actor_ids":null,"tags":null}]`
+
+	tests := []struct {
+		name       string
+		query      string
+		respCode   int
+		respError  string
+		embedError error
+		// noMovies makes embedding find no movies of the actor
+		noMovies bool
+		// respBody is expected JSON of response when set
+		respBody string
+	}{
+		{
+			name:     "Include movies",
+			query:    "include=movies",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","actors":[{"id":1,"name":"Al Pacino","sex":"male","birthdate":"0001-01-01T00:00:00Z","age":84,"movie_ids":[2],
+				"movies":` + moviesJSON + `,"tags":null}]}`,
+		},
+		{
+			name:     "Sparse fields",
+			query:    "fields=name,age",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","actors":[{"id":1,"name":"Al Pacino","age":84}]}`,
+		},
+		{
+			name:     "Sparse fields with movies",
+			query:    "fields=name&include=movies",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","actors":[{"id":1,"name":"Al Pacino","movies":` + moviesJSON + `}]}`,
+		},
+		{
+			name:     "Include movies of actor without movies",
+			query:    "include=movies",
+			noMovies: true,
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","actors":[{"id":1,"name":"Al Pacino","sex":"male","birthdate":"0001-01-01T00:00:00Z","age":84,"movie_ids":[2],
+				"movies":[],"tags":null}]}`,
+		},
+		{
+			name:     "Sparse fields with no movies",
+			query:    "fields=name&include=movies",
+			noMovies: true,
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","actors":[{"id":1,"name":"Al Pacino","movies":[]}]}`,
+		},
+		{
+			name:      "Unknown include",
+			query:     "include=awards",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse include",
+		},
+		{
+			name:      "Unknown field",
+			query:     "fields=name,height",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse fields",
+		},
+		{
+			name:       "EmbedMovies error",
+			query:      "include=movies",
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get actors",
+			embedError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorGetterMock := mocks.NewActorGetter(t)
+			actorGetterMock.
+				On("GetActors", 10, 0, &entity.ActorFilter{}, []string{"en"}).
+				Return(append([]entity.Actor(nil), actors...), nil).
+				Maybe()
+			actorGetterMock.
+				On("EmbedMovies", mock.AnythingOfType("[]entity.Actor"), []string{"en"}).
+				Run(func(args mock.Arguments) {
+					embedded := movies
+					if tt.noMovies {
+						embedded = []entity.Movie{}
+					}
+					args.Get(0).([]entity.Actor)[0].Movies = &embedded
+				}).
+				Return(tt.embedError).
+				Maybe()
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), actorGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			if tt.respBody != "" {
+				require.JSONEq(t, tt.respBody, rr.Body.String())
+				return
+			}
+			var resp resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
+
+func TestActorQueryLastModified(t *testing.T) {
+	actors := []entity.Actor{
+		{ID: 1, UpdatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		respCode        int
+	}{
+		{
+			name:            "Not modified since",
+			ifModifiedSince: "Wed, 03 Jan 2024 00:00:00 GMT",
+			respCode:        http.StatusNotModified,
+		},
+		{
+			name:            "Modified since",
+			ifModifiedSince: "Tue, 02 Jan 2024 00:00:00 GMT",
+			respCode:        http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorGetterMock := mocks.NewActorGetter(t)
+			actorGetterMock.
+				On("GetActors", mock.AnythingOfType("int"), mock.AnythingOfType("int"), &entity.ActorFilter{}, []string{"en"}).
+				Return(actors, nil)
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), actorGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+			req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			// List was last modified with its latest actor
+			require.Equal(t, "Wed, 03 Jan 2024 00:00:00 GMT", rr.Header().Get("Last-Modified"))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package restore
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorRestorer
+type ActorRestorer interface {
+	RestoreActor(id int, audit entity.AuditInfo) error
+	GetActorById(id int, langs []string) (*entity.Actor, error)
+}
+
+type Response struct {
+	resp.Response
+	Actor *entity.Actor `json:"actor"`
+}
+
+// New restores deleted actor together with their movies and other links
+func New(log *slog.Logger, actorRestorer ActorRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.restore.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		if err := actorRestorer.RestoreActor(id, audit.FromRequest(r)); err != nil {
+			log.Error("Failed to restore actor", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to restore actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		actor, err := actorRestorer.GetActorById(id, nil)
+		if err != nil {
+			log.Error("Failed to get actor", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Actor:    actor,
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorTagAdder
+type ActorTagAdder interface {
+	AddActorTag(actorID int, tag string) error
+}
+
+type Response struct {
+	resp.Response
+	Tag string `json:"tag,omitempty"`
+}
+
+// New attaches tag to actor, any authenticated user may tag actors
+func New(log *slog.Logger, actorTagAdder ActorTagAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.tags.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		actorID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse actor id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse actor id"))
+			return
+		}
+
+		var newTag entity.NewTag
+		if err := render.DecodeJSON(r.Body, &newTag); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(newTag); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		tag, ok := entity.NormalizeTag(newTag.Name)
+		if !ok {
+			log.Error("Invalid tag", slog.String("tag", newTag.Name))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid tag"))
+			return
+		}
+
+		if err := actorTagAdder.AddActorTag(actorID, tag); err != nil {
+			log.Error("Failed to add tag", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to add tag")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Tag:      tag,
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package create_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/tags/create"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/tags/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestActorTagCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		body      string
+		tag       string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			body:     `{"name": " Cult  Classic "}`,
+			tag:      "cult classic",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      `{"name": "noir"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse actor id",
+		},
+		{
+			name:      "Bad body",
+			id:        "1",
+			body:      `{`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid request",
+		},
+		{
+			name:      "Missing name",
+			id:        "1",
+			body:      `{}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Name is required",
+		},
+		{
+			name:      "Invalid tag",
+			id:        "1",
+			body:      fmt.Sprintf(`{"name": "%s"}`, strings.Repeat("a", 51)),
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid tag",
+		},
+		{
+			name:      "Actor not found",
+			id:        "1",
+			body:      `{"name": "noir"}`,
+			tag:       "noir",
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "AddActorTag error",
+			id:        "1",
+			body:      `{"name": "noir"}`,
+			tag:       "noir",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to add tag",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorTagAdderMock := mocks.NewActorTagAdder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				actorTagAdderMock.
+					On("AddActorTag", 1, tt.tag).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), actorTagAdderMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/tags", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/tags", tt.id), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("x-role", "user")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, tt.tag, resp.Tag)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorTagRemover
+type ActorTagRemover interface {
+	RemoveActorTag(actorID int, tag string) error
+}
+
+func New(log *slog.Logger, actorTagRemover ActorTagRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.tags.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		actorID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse actor id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse actor id"))
+			return
+		}
+
+		tag, ok := entity.NormalizeTag(r.PathValue("tag"))
+		if !ok {
+			log.Error("Invalid tag", slog.String("tag", r.PathValue("tag")))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid tag"))
+			return
+		}
+
+		if err := actorTagRemover.RemoveActorTag(actorID, tag); err != nil {
+			log.Error("Failed to remove tag", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove tag")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,57 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorTranslationRemover
+type ActorTranslationRemover interface {
+	RemoveActorTranslation(actorID int, lang string) error
+}
+
+func New(log *slog.Logger, actorTranslationRemover ActorTranslationRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.translations.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		actorID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse actor id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse actor id"))
+			return
+		}
+
+		lang, ok := locale.Normalize(r.PathValue("lang"))
+		if !ok {
+			log.Error("Invalid language", slog.String("lang", r.PathValue("lang")))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid language"))
+			return
+		}
+
+		if err := actorTranslationRemover.RemoveActorTranslation(actorID, lang); err != nil {
+			log.Error("Failed to remove translation", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove translation")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,91 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	actorTranslationsQuery "github.com/rmntim/movielab/internal/server/handlers/actors/translations/query"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/translations/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorTranslationsQuery(t *testing.T) {
+	translations := []entity.ActorTranslation{
+		{Lang: "fr", NewActorTranslation: entity.NewActorTranslation{Name: "Jean"}},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		mockBody  []entity.ActorTranslation
+		respBody  []entity.ActorTranslation
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			mockBody: translations,
+			respBody: translations,
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No translations",
+			id:       "1",
+			respBody: []entity.ActorTranslation{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse actor id",
+		},
+		{
+			name:      "GetActorTranslations error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get translations",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorTranslationsGetterMock := mocks.NewActorTranslationsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				actorTranslationsGetterMock.
+					On("GetActorTranslations", 1).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := actorTranslationsQuery.New(slogdiscard.NewDiscardLogger(), actorTranslationsGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /{id}/translations", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/translations", tt.id), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp actorTranslationsQuery.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+			require.Equal(t, tt.respBody, resp.Translations)
+		})
+	}
+}
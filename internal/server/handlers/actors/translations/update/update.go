@@ -0,0 +1,86 @@
+package update
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorTranslationSetter
+type ActorTranslationSetter interface {
+	SetActorTranslation(actorID int, translation *entity.ActorTranslation) error
+}
+
+type Response struct {
+	resp.Response
+	Translation *entity.ActorTranslation `json:"translation,omitempty"`
+}
+
+func New(log *slog.Logger, actorTranslationSetter ActorTranslationSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.actors.translations.update.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		actorID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse actor id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse actor id"))
+			return
+		}
+
+		lang, ok := locale.Normalize(r.PathValue("lang"))
+		if !ok {
+			log.Error("Invalid language", slog.String("lang", r.PathValue("lang")))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid language"))
+			return
+		}
+
+		translation := entity.ActorTranslation{Lang: lang}
+		if err := render.DecodeJSON(r.Body, &translation.NewActorTranslation); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(translation); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		if err := actorTranslationSetter.SetActorTranslation(actorID, &translation); err != nil {
+			log.Error("Failed to set translation", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to set translation")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response:    resp.Ok(),
+			Translation: &translation,
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	actorTranslationsUpdate "github.com/rmntim/movielab/internal/server/handlers/actors/translations/update"
+	"github.com/rmntim/movielab/internal/server/handlers/actors/translations/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorTranslationUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      string
+		lang      string
+		body      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			role:     "admin",
+			lang:     "pt-BR",
+			body:     `{"name": "João"}`,
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Not admin",
+			role:      "user",
+			lang:      "pt-BR",
+			body:      `{"name": "João"}`,
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Invalid language",
+			role:      "admin",
+			lang:      "portuguese!",
+			body:      `{"name": "João"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid language",
+		},
+		{
+			name:      "Invalid body",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid request",
+		},
+		{
+			name:      "Missing name",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Name is required",
+		},
+		{
+			name:      "Actor not found",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{"name": "João"}`,
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
+		},
+		{
+			name:      "SetActorTranslation error",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{"name": "João"}`,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to set translation",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorTranslationSetterMock := mocks.NewActorTranslationSetter(t)
+
+			var translation entity.ActorTranslation
+			_ = json.Unmarshal([]byte(tt.body), &translation.NewActorTranslation)
+			translation.Lang = "pt-br"
+
+			if tt.respError == "" || tt.mockError != nil {
+				actorTranslationSetterMock.
+					On("SetActorTranslation", 1, &translation).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := actorTranslationsUpdate.New(slogdiscard.NewDiscardLogger(), actorTranslationSetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /{id}/translations/{lang}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/1/translations/%s", tt.lang), bytes.NewReader([]byte(tt.body)))
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp actorTranslationsUpdate.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, &translation, resp.Translation)
+			}
+		})
+	}
+}
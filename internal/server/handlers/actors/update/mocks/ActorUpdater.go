@@ -12,9 +12,9 @@ type ActorUpdater struct {
 	mock.Mock
 }
 
-// GetActorById provides a mock function with given fields: id
-func (_m *ActorUpdater) GetActorById(id int) (*entity.Actor, error) {
-	ret := _m.Called(id)
+// GetActorById provides a mock function with given fields: id, langs
+func (_m *ActorUpdater) GetActorById(id int, langs []string) (*entity.Actor, error) {
+	ret := _m.Called(id, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActorById")
@@ -22,19 +22,19 @@ func (_m *ActorUpdater) GetActorById(id int) (*entity.Actor, error) {
 
 	var r0 *entity.Actor
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*entity.Actor, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Actor, error)); ok {
+		return rf(id, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int) *entity.Actor); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Actor); ok {
+		r0 = rf(id, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Actor)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,17 +42,17 @@ func (_m *ActorUpdater) GetActorById(id int) (*entity.Actor, error) {
 	return r0, r1
 }
 
-// UpdateActor provides a mock function with given fields: id, actor
-func (_m *ActorUpdater) UpdateActor(id int, actor *entity.Actor) error {
-	ret := _m.Called(id, actor)
+// UpdateActor provides a mock function with given fields: id, actor, audit
+func (_m *ActorUpdater) UpdateActor(id int, actor *entity.Actor, audit entity.AuditInfo) error {
+	ret := _m.Called(id, actor, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateActor")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, *entity.Actor) error); ok {
-		r0 = rf(id, actor)
+	if rf, ok := ret.Get(0).(func(int, *entity.Actor, entity.AuditInfo) error); ok {
+		r0 = rf(id, actor, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,21 +1,30 @@
 package update
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/patch"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"github.com/rmntim/movielab/internal/storage"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorUpdater
 type ActorUpdater interface {
-	GetActorById(id int) (*entity.Actor, error)
-	UpdateActor(id int, actor *entity.Actor) error
+	GetActorById(id int, langs []string) (*entity.Actor, error)
+	UpdateActor(id int, actor *entity.Actor, audit entity.AuditInfo) error
 }
 
 type Response struct {
@@ -23,7 +32,9 @@ type Response struct {
 	resp.Response
 }
 
-func New(log *slog.Logger, actorUpdater ActorUpdater) http.HandlerFunc {
+// New replaces fields of actor with JSON body on PUT, or applies JSON Merge Patch or JSON Patch body to them on PATCH.
+// If-Match header is checked against ETag of the actor when present.
+func New(log *slog.Logger, actorUpdater ActorUpdater, requireIfMatch bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actors.update.New"
 
@@ -44,37 +55,125 @@ func New(log *slog.Logger, actorUpdater ActorUpdater) http.HandlerFunc {
 			return
 		}
 
-		oldActor, err := actorUpdater.GetActorById(id)
-		if err != nil {
-			if errors.Is(err, storage.ErrActorNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("Actor not found"))
+		// PUT takes all fields of the actor, PATCH takes one of patch documents
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if r.Method == http.MethodPatch {
+			if mediaType != patch.MergePatch && mediaType != patch.JSONPatch {
+				log.Error("Unsupported content type", slog.String("content_type", mediaType))
+				w.Header().Set("Accept-Patch", patch.Accepted)
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				render.JSON(w, r, resp.Error("Unsupported content type"))
 				return
 			}
+		} else if mediaType != "application/json" {
+			log.Error("Unsupported content type", slog.String("content_type", mediaType))
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			render.JSON(w, r, resp.Error("Unsupported content type"))
+			return
+		}
+
+		ifMatch := r.Header.Get("If-Match")
+		if ifMatch == "" && requireIfMatch {
+			log.Error("Missing If-Match header")
+			w.WriteHeader(http.StatusPreconditionRequired)
+			render.JSON(w, r, resp.Error("If-Match header is required"))
+			return
+		}
+
+		// Updates are applied to original, untranslated fields
+		oldActor, err := actorUpdater.GetActorById(id, nil)
+		if err != nil {
 			log.Error("Failed to get actor", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get actor"))
+			status, errResp := resp.StorageError(err, "Failed to get actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
+		if ifMatch != "" && !etag.Match(ifMatch, oldActor.Version) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Actor was modified"))
+			return
+		}
+
+		// Version of the read actor is expected by update, so concurrent changes are not overwritten
 		newActor := *oldActor
-		if err := render.DecodeJSON(r.Body, &newActor); err != nil {
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("Failed to read body", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse body"))
+			return
+		}
+
+		if r.Method == http.MethodPatch {
+			original := oldActor.NewActor
+			document, err := json.Marshal(original)
+			if err != nil {
+				log.Error("Failed to encode actor", sl.Err(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("Failed to update actor"))
+				return
+			}
+
+			body, err = patch.Apply(mediaType, document, body)
+			if err != nil {
+				log.Error("Failed to apply patch", sl.Err(err))
+				if errors.Is(err, patch.ErrConflict) {
+					w.WriteHeader(http.StatusConflict)
+					render.JSON(w, r, resp.Error("Patch does not apply"))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Invalid patch"))
+				return
+			}
+		}
+
+		// Fields missing in body, or removed by patch, are cleared
+		newActor.NewActor = entity.NewActor{}
+		if err := json.Unmarshal(body, &newActor.NewActor); err != nil {
 			log.Error("Failed to parse body", sl.Err(err))
 			w.WriteHeader(http.StatusBadRequest)
 			render.JSON(w, r, resp.Error("Failed to parse body"))
 			return
 		}
 
-		if err := actorUpdater.UpdateActor(id, &newActor); err != nil {
+		if err := validate.Struct(newActor.NewActor); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		newActor.Age = newActor.AgeAt(time.Now())
+
+		if err := actorUpdater.UpdateActor(id, &newActor, audit.FromRequest(r)); err != nil {
+			if errors.Is(err, storage.ErrVersionConflict) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Actor was modified"))
+				return
+			}
 			log.Error("Failed to update actor", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to update actor"))
+			status, errResp := resp.StorageError(err, "Failed to update actor")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
-		render.JSON(w, r, Response{
+		response := Response{
 			Response: resp.Ok(),
 			Actor:    &newActor,
-		})
+		}
+		// Tagged like GET, so that both tell apart the same representations; actor is updated even if tagging fails
+		if tag, err := etag.Tag(newActor.Version, response); err != nil {
+			log.Error("Failed to tag actor", sl.Err(err))
+		} else {
+			w.Header().Set("ETag", tag)
+		}
+		render.JSON(w, r, response)
 	}
 }
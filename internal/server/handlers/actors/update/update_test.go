@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/patch"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/update"
 	"github.com/rmntim/movielab/internal/server/handlers/actors/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -19,32 +22,208 @@ import (
 
 var (
 	errBadId       = errors.New("failed to parse id")
-	errMovieUpdate = errors.New("failed to update actor")
-	errMovieGet    = errors.New("failed to get actor")
+	errActorUpdate = errors.New("failed to update actor")
+	errActorGet    = errors.New("failed to get actor")
 )
 
+var validActor = &entity.Actor{
+	ID: 1,
+	NewActor: entity.NewActor{
+		Name:      "Test",
+		Sex:       "female",
+		Gender:    "Test",
+		BirthDate: time.Now(),
+	},
+}
+
+// storedActor is returned by GetActorById
+var storedActor = entity.NewActor{
+	Name:       "Sigourney Weaver",
+	Sex:        "female",
+	Gender:     "female",
+	BirthDate:  time.Date(1949, 10, 8, 0, 0, 0, 0, time.UTC),
+	Birthplace: "New York City",
+	Aliases:    []string{"Susan Weaver"},
+}
+
 func TestActorUpdate(t *testing.T) {
 	tests := []struct {
-		name      string
-		id        string
-		reqActor  *entity.Actor
-		role      string
-		respCode  int
-		respError string
-		mockError error
+		name     string
+		id       string
+		reqActor *entity.Actor
+		// method is PUT by default
+		method string
+		// body is sent instead of reqActor when set
+		body string
+		// contentType is application/json by default
+		contentType string
+		role        string
+		respCode    int
+		respError   string
+		mockError   error
+		ifMatch     string
+		// requireIfMatch is passed to handler
+		requireIfMatch bool
+		// tagged tells if response carries ETag of updated version
+		tagged bool
+		// updated is expected to be passed to UpdateActor when set
+		updated *entity.NewActor
 	}{
 		{
-			name: "Success",
-			id:   "1",
-			reqActor: &entity.Actor{
-				ID: 1,
-				NewActor: entity.NewActor{
-					Name:      "Test",
-					Sex:       "Test",
-					BirthDate: time.Now(),
-				},
+			name:     "Success",
+			id:       "1",
+			reqActor: validActor,
+			respCode: http.StatusOK,
+			tagged:   true,
+		},
+		{
+			name:     "Put replaces all fields",
+			id:       "1",
+			body:     `{"name":"Susan Weaver","sex":"female","birthdate":"1949-10-08T00:00:00Z"}`,
+			respCode: http.StatusOK,
+			tagged:   true,
+			updated: &entity.NewActor{
+				Name:      "Susan Weaver",
+				Sex:       "female",
+				BirthDate: storedActor.BirthDate,
 			},
+		},
+		{
+			name:      "Put without name",
+			id:        "1",
+			body:      `{"sex":"female"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Name is required",
+		},
+		{
+			name:      "Put with future death date",
+			id:        "1",
+			body:      `{"name":"Susan Weaver","sex":"female","birthdate":"1949-10-08T00:00:00Z","deathdate":"2999-01-01T00:00:00Z"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field DeathDate is invalid",
+		},
+		{
+			name:      "Put with unknown sex",
+			id:        "1",
+			body:      `{"name":"Susan Weaver","sex":"non-binary","birthdate":"1949-10-08T00:00:00Z"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Sex is invalid",
+		},
+		{
+			name:        "Put of unsupported type",
+			id:          "1",
+			reqActor:    validActor,
+			contentType: "application/xml",
+			respCode:    http.StatusUnsupportedMediaType,
+			respError:   "Unsupported content type",
+		},
+		{
+			name:        "Merge patch",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"birthplace":null,"biography":"Ripley"}`,
+			contentType: patch.MergePatch,
+			respCode:    http.StatusOK,
+			tagged:      true,
+			updated: &entity.NewActor{
+				Name:      storedActor.Name,
+				Sex:       storedActor.Sex,
+				Gender:    storedActor.Gender,
+				BirthDate: storedActor.BirthDate,
+				Biography: "Ripley",
+				Aliases:   storedActor.Aliases,
+			},
+		},
+		{
+			name:        "JSON patch of aliases",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `[{"op":"add","path":"/aliases/0","value":"Weaver"}]`,
+			contentType: patch.JSONPatch,
+			respCode:    http.StatusOK,
+			tagged:      true,
+			updated: &entity.NewActor{
+				Name:       storedActor.Name,
+				Sex:        storedActor.Sex,
+				Gender:     storedActor.Gender,
+				BirthDate:  storedActor.BirthDate,
+				Birthplace: storedActor.Birthplace,
+				Aliases:    []string{"Weaver", "Susan Weaver"},
+			},
+		},
+		{
+			name:        "JSON patch of missing field",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `[{"op":"replace","path":"/deathdate","value":"2000-01-01T00:00:00Z"}]`,
+			contentType: patch.JSONPatch,
+			respCode:    http.StatusConflict,
+			respError:   "Patch does not apply",
+		},
+		{
+			name:        "Malformed patch",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"name":`,
+			contentType: patch.MergePatch,
+			respCode:    http.StatusBadRequest,
+			respError:   "Invalid patch",
+		},
+		{
+			name:      "Patch without content type",
+			id:        "1",
+			method:    http.MethodPatch,
+			body:      `{"name":"Weaver"}`,
+			respCode:  http.StatusUnsupportedMediaType,
+			respError: "Unsupported content type",
+		},
+		{
+			name:     "Success with If-Match",
+			id:       "1",
+			reqActor: validActor,
+			ifMatch:  `"3"`,
 			respCode: http.StatusOK,
+			tagged:   true,
+		},
+		{
+			name:      "If-Match mismatch",
+			id:        "1",
+			reqActor:  validActor,
+			ifMatch:   `"2"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+		},
+		{
+			name:           "If-Match required",
+			id:             "1",
+			reqActor:       validActor,
+			requireIfMatch: true,
+			respCode:       http.StatusPreconditionRequired,
+			respError:      "If-Match header is required",
+		},
+		{
+			name:      "Concurrent update",
+			id:        "1",
+			reqActor:  validActor,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Actor was modified",
+			mockError: storage.ErrVersionConflict,
+		},
+		{
+			name:      "Constraint violated",
+			id:        "1",
+			reqActor:  validActor,
+			respCode:  http.StatusUnprocessableEntity,
+			respError: "field deathdate is invalid",
+			mockError: &storage.ConstraintError{Err: storage.ErrConstraintViolated, Constraint: "death_date_check", Field: "deathdate"},
+		},
+		{
+			name:      "Deleted meanwhile",
+			id:        "1",
+			reqActor:  validActor,
+			respCode:  http.StatusNotFound,
+			respError: "Actor not found",
+			mockError: storage.ErrActorNotFound,
 		},
 		{
 			name:      "Bad Id",
@@ -61,34 +240,20 @@ func TestActorUpdate(t *testing.T) {
 			respError: "Insufficient permissions",
 		},
 		{
-			name: "GetActorById Error",
-			id:   "1",
-			reqActor: &entity.Actor{
-				ID: 1,
-				NewActor: entity.NewActor{
-					Name:      "Test",
-					Sex:       "Test",
-					BirthDate: time.Now(),
-				},
-			},
+			name:      "GetActorById Error",
+			id:        "1",
+			reqActor:  validActor,
 			respCode:  http.StatusInternalServerError,
 			respError: "Failed to get actor",
-			mockError: errMovieGet,
-		},
-		{
-			name: "UpdateActor Error",
-			id:   "1",
-			reqActor: &entity.Actor{
-				ID: 1,
-				NewActor: entity.NewActor{
-					Name:      "Test",
-					Sex:       "Test",
-					BirthDate: time.Now(),
-				},
-			},
+			mockError: errActorGet,
+		},
+		{
+			name:      "UpdateActor Error",
+			id:        "1",
+			reqActor:  validActor,
 			respCode:  http.StatusInternalServerError,
 			respError: "Failed to update actor",
-			mockError: errMovieUpdate,
+			mockError: errActorUpdate,
 		},
 	}
 
@@ -99,24 +264,43 @@ func TestActorUpdate(t *testing.T) {
 
 			actorUpdaterMock := mocks.NewActorUpdater(t)
 
-			if tt.respError == "" || tt.mockError != nil {
-				if errors.Is(tt.mockError, errMovieGet) {
-					actorUpdaterMock.On("GetActorById", mock.AnythingOfType("int")).Return(&entity.Actor{}, tt.mockError).Maybe()
-				} else {
-					actorUpdaterMock.On("GetActorById", mock.AnythingOfType("int")).Return(&entity.Actor{}, nil).Maybe()
-					actorUpdaterMock.On("UpdateActor", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.Actor")).Return(tt.mockError).Maybe()
-				}
+			var updated entity.NewActor
+			if errors.Is(tt.mockError, errActorGet) {
+				actorUpdaterMock.On("GetActorById", mock.AnythingOfType("int"), []string(nil)).Return(&entity.Actor{}, tt.mockError).Maybe()
+			} else {
+				actorUpdaterMock.On("GetActorById", mock.AnythingOfType("int"), []string(nil)).Return(&entity.Actor{NewActor: storedActor, Version: 3}, nil).Maybe()
+				actorUpdaterMock.On("UpdateActor", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.Actor"), entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
+					Run(func(args mock.Arguments) {
+						actor := args.Get(1).(*entity.Actor)
+						updated = actor.NewActor
+						actor.Version++
+					}).
+					Return(tt.mockError).
+					Maybe()
 			}
 
-			handler := update.New(slogdiscard.NewDiscardLogger(), actorUpdaterMock)
+			handler := update.New(slogdiscard.NewDiscardLogger(), actorUpdaterMock, tt.requireIfMatch)
 
-			input, err := json.Marshal(tt.reqActor)
-			require.NoError(t, err)
+			input := []byte(tt.body)
+			if tt.body == "" {
+				var err error
+				input, err = json.Marshal(tt.reqActor)
+				require.NoError(t, err)
+			}
+
+			method := http.MethodPut
+			contentType := "application/json"
+			if tt.method != "" {
+				method = tt.method
+				contentType = tt.contentType
+			} else if tt.contentType != "" {
+				contentType = tt.contentType
+			}
 
 			mux := http.NewServeMux()
-			mux.HandleFunc("PUT /{id}", handler)
+			mux.HandleFunc("/{id}", handler)
 
-			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s", tt.id), bytes.NewBuffer(input))
+			req, err := http.NewRequest(method, fmt.Sprintf("/%s", tt.id), bytes.NewBuffer(input))
 			require.NoError(t, err)
 
 			role := "admin"
@@ -124,14 +308,32 @@ func TestActorUpdate(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, tt.respCode)
+			require.Equal(t, tt.respCode, rr.Code)
 			body := rr.Body.String()
 			var resp update.Response
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 			require.Equal(t, tt.respError, resp.Error)
+			if tt.tagged {
+				tag, err := etag.Tag(4, resp)
+				require.NoError(t, err)
+				require.Equal(t, tag, rr.Header().Get("ETag"))
+			} else {
+				require.Empty(t, rr.Header().Get("ETag"))
+			}
+			if tt.updated != nil {
+				require.Equal(t, *tt.updated, updated)
+			}
 		})
 	}
 }
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=AuditGetter
+type AuditGetter interface {
+	GetAuditLog(filter *entity.AuditFilter, limit, offset int) ([]entity.AuditEntry, error)
+}
+
+type Response struct {
+	resp.Response
+	Entries []entity.AuditEntry `json:"entries"`
+}
+
+// New lists changes made to movies and actors, most recent first
+func New(log *slog.Logger, auditGetter AuditGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.audit.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		filter := entity.AuditFilter{
+			Username:   r.URL.Query().Get("username"),
+			Action:     r.URL.Query().Get("action"),
+			EntityType: r.URL.Query().Get("entity_type"),
+		}
+		if entityID := r.URL.Query().Get("entity_id"); entityID != "" {
+			filter.EntityID, err = strconv.Atoi(entityID)
+			if err != nil {
+				log.Error("Failed to parse entity_id", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse entity_id"))
+				return
+			}
+		}
+		times := []struct {
+			param string
+			dst   **time.Time
+		}{
+			{"from", &filter.From},
+			{"to", &filter.To},
+		}
+		for _, t := range times {
+			value := r.URL.Query().Get(t.param)
+			if value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				log.Error("Failed to parse "+t.param, sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse "+t.param))
+				return
+			}
+			*t.dst = &parsed
+		}
+
+		if err := validate.Struct(filter); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		entries, err := auditGetter.GetAuditLog(&filter, limit, offset)
+		if err != nil {
+			log.Error("Failed to get audit log", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get audit log")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if entries == nil {
+			entries = []entity.AuditEntry{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Entries:  entries,
+		})
+	}
+}
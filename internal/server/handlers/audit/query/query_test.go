@@ -0,0 +1,141 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/audit/query"
+	"github.com/rmntim/movielab/internal/server/handlers/audit/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuditQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		role       string
+		mockFilter *entity.AuditFilter
+		mockLimit  int
+		mockOffset int
+		respBody   []entity.AuditEntry
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success",
+			role:       "admin",
+			mockFilter: &entity.AuditFilter{},
+			mockLimit:  10,
+			respBody:   []entity.AuditEntry{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:  "Success with filter",
+			query: "username=admin&action=delete&entity_type=actor&entity_id=3&from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z&limit=5&offset=10",
+			role:  "admin",
+			mockFilter: &entity.AuditFilter{
+				Username:   "admin",
+				Action:     entity.AuditDelete,
+				EntityType: entity.AuditActor,
+				EntityID:   3,
+				From:       &from,
+				To:         &to,
+			},
+			mockLimit:  5,
+			mockOffset: 10,
+			respBody:   []entity.AuditEntry{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad entity id",
+			query:     "entity_id=a",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse entity_id",
+		},
+		{
+			name:      "Bad from",
+			query:     "from=2024-01-01",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse from",
+		},
+		{
+			name:      "Invalid action",
+			query:     "action=read",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "field Action is invalid",
+		},
+		{
+			name:      "Invalid entity type",
+			query:     "entity_type=user",
+			role:      "admin",
+			respCode:  http.StatusBadRequest,
+			respError: "field EntityType is invalid",
+		},
+		{
+			name:       "GetAuditLog error",
+			role:       "admin",
+			mockFilter: &entity.AuditFilter{},
+			mockLimit:  10,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get audit log",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auditGetterMock := mocks.NewAuditGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				auditGetterMock.
+					On("GetAuditLog", tt.mockFilter, tt.mockLimit, tt.mockOffset).
+					Return(nil, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), auditGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Entries)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
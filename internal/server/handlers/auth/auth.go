@@ -6,6 +6,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
@@ -42,7 +43,7 @@ func New(log *slog.Logger, userGetter UserRoleGetter, secret string) http.Handle
 
 		log.Info("Request decoded")
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			log.Error("Invalid request", sl.Err(err))
 			w.WriteHeader(http.StatusBadRequest)
 			var validationErr validator.ValidationErrors
@@ -0,0 +1,78 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=AwardCategoryCreator
+type AwardCategoryCreator interface {
+	CreateAwardCategory(awardID int, category *entity.NewAwardCategory) (int, error)
+}
+
+type Response struct {
+	resp.Response
+	Category *entity.AwardCategory `json:"category,omitempty"`
+}
+
+func New(log *slog.Logger, categoryCreator AwardCategoryCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.awards.categories.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		awardID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse award id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse award id"))
+			return
+		}
+
+		var category entity.NewAwardCategory
+		if err := render.DecodeJSON(r.Body, &category); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(category); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		id, err := categoryCreator.CreateAwardCategory(awardID, &category)
+		if err != nil {
+			log.Error("Failed to create award category", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to create award category")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Category: &entity.AwardCategory{ID: id, NewAwardCategory: category},
+		})
+	}
+}
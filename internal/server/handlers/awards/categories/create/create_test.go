@@ -0,0 +1,122 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/categories/create"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/categories/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAwardCategoryCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		reqCategory *entity.NewAwardCategory
+		role        string
+		respCode    int
+		respError   string
+		mockError   error
+	}{
+		{
+			name:        "Success",
+			id:          "1",
+			reqCategory: &entity.NewAwardCategory{Name: "Best Picture"},
+			respCode:    http.StatusOK,
+		},
+		{
+			name:        "Empty name",
+			id:          "1",
+			reqCategory: &entity.NewAwardCategory{},
+			respCode:    http.StatusBadRequest,
+			respError:   "field Name is required",
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse award id",
+		},
+		{
+			name:        "Award not found",
+			id:          "1",
+			reqCategory: &entity.NewAwardCategory{Name: "Best Picture"},
+			respCode:    http.StatusNotFound,
+			respError:   "Award not found",
+			mockError:   storage.ErrAwardNotFound,
+		},
+		{
+			name:        "Category exists",
+			id:          "1",
+			reqCategory: &entity.NewAwardCategory{Name: "Best Picture"},
+			respCode:    http.StatusConflict,
+			respError:   "Award category already exists",
+			mockError:   storage.ErrAwardCategoryExists,
+		},
+		{
+			name:        "CreateAwardCategory error",
+			id:          "1",
+			reqCategory: &entity.NewAwardCategory{Name: "Best Picture"},
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to create award category",
+			mockError:   errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			categoryCreatorMock := mocks.NewAwardCategoryCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				categoryCreatorMock.On("CreateAwardCategory", 1, tt.reqCategory).Return(2, tt.mockError).Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), categoryCreatorMock)
+
+			input, err := json.Marshal(tt.reqCategory)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/categories", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/categories", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, &entity.AwardCategory{ID: 2, NewAwardCategory: *tt.reqCategory}, resp.Category)
+			}
+		})
+	}
+}
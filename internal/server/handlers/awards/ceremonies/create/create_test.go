@@ -0,0 +1,134 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/ceremonies/create"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/ceremonies/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCeremonyCreate(t *testing.T) {
+	heldOn := time.Date(1998, time.March, 23, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		id          string
+		reqCeremony *entity.NewCeremony
+		role        string
+		respCode    int
+		respError   string
+		mockError   error
+	}{
+		{
+			name:        "Success",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{Year: 1998, HeldOn: &heldOn},
+			respCode:    http.StatusOK,
+		},
+		{
+			name:        "Missing year",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{},
+			respCode:    http.StatusBadRequest,
+			respError:   "field Year is required",
+		},
+		{
+			name:        "Year out of range",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{Year: 1500},
+			respCode:    http.StatusBadRequest,
+			respError:   "field Year is invalid",
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse award id",
+		},
+		{
+			name:        "Award not found",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{Year: 1998},
+			respCode:    http.StatusNotFound,
+			respError:   "Award not found",
+			mockError:   storage.ErrAwardNotFound,
+		},
+		{
+			name:        "Ceremony exists",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{Year: 1998},
+			respCode:    http.StatusConflict,
+			respError:   "Ceremony already exists",
+			mockError:   storage.ErrCeremonyExists,
+		},
+		{
+			name:        "CreateCeremony error",
+			id:          "1",
+			reqCeremony: &entity.NewCeremony{Year: 1998},
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to create ceremony",
+			mockError:   errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ceremonyCreatorMock := mocks.NewCeremonyCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				ceremonyCreatorMock.On("CreateCeremony", 1, mock.AnythingOfType("*entity.NewCeremony")).Return(2, tt.mockError).Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), ceremonyCreatorMock)
+
+			input, err := json.Marshal(tt.reqCeremony)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/ceremonies", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/ceremonies", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, 2, resp.Ceremony.ID)
+				require.Equal(t, tt.reqCeremony.Year, resp.Ceremony.Year)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=AwardCreator
+type AwardCreator interface {
+	CreateAward(award *entity.NewAward) (int, error)
+}
+
+type Response struct {
+	resp.Response
+	Award *entity.Award `json:"award,omitempty"`
+}
+
+func New(log *slog.Logger, awardCreator AwardCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.awards.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		var award entity.NewAward
+		if err := render.DecodeJSON(r.Body, &award); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(award); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		id, err := awardCreator.CreateAward(&award)
+		if err != nil {
+			log.Error("Failed to create award", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to create award")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Award: &entity.Award{
+				ID:         id,
+				NewAward:   award,
+				Categories: []entity.AwardCategory{},
+				Ceremonies: []entity.Ceremony{},
+			},
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/create"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAwardCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		reqAward  *entity.NewAward
+		role      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			reqAward: &entity.NewAward{Name: "Academy Awards"},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Empty name",
+			reqAward:  &entity.NewAward{},
+			respCode:  http.StatusBadRequest,
+			respError: "field Name is required",
+		},
+		{
+			name:      "Unauthorized",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Award exists",
+			reqAward:  &entity.NewAward{Name: "Academy Awards"},
+			respCode:  http.StatusConflict,
+			respError: "Award already exists",
+			mockError: storage.ErrAwardExists,
+		},
+		{
+			name:      "CreateAward error",
+			reqAward:  &entity.NewAward{Name: "Academy Awards"},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to create award",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			awardCreatorMock := mocks.NewAwardCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				awardCreatorMock.On("CreateAward", tt.reqAward).Return(1, tt.mockError).Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), awardCreatorMock)
+
+			input, err := json.Marshal(tt.reqAward)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, 1, resp.Award.ID)
+				require.Equal(t, tt.reqAward.Name, resp.Award.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=AwardDeleter
+type AwardDeleter interface {
+	DeleteAward(id int) error
+}
+
+// New deletes award together with its categories, ceremonies and nominations
+func New(log *slog.Logger, awardDeleter AwardDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.awards.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		if err := awardDeleter.DeleteAward(id); err != nil {
+			log.Error("Failed to delete award", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to delete award")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
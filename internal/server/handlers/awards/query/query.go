@@ -0,0 +1,45 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=AwardGetter
+type AwardGetter interface {
+	GetAwards() ([]entity.Award, error)
+}
+
+type Response struct {
+	resp.Response
+	Awards []entity.Award `json:"awards"`
+}
+
+func New(log *slog.Logger, awardGetter AwardGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.awards.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		awards, err := awardGetter.GetAwards()
+		if err != nil {
+			log.Error("Failed to get awards", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get awards")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if awards == nil {
+			awards = []entity.Award{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Awards:   awards,
+		})
+	}
+}
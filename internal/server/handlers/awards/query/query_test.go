@@ -0,0 +1,78 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/query"
+	"github.com/rmntim/movielab/internal/server/handlers/awards/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAwardQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockBody  []entity.Award
+		respBody  []entity.Award
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			mockBody: []entity.Award{{
+				ID:         1,
+				NewAward:   entity.NewAward{Name: "Academy Awards"},
+				Categories: []entity.AwardCategory{{ID: 1, NewAwardCategory: entity.NewAwardCategory{Name: "Best Picture"}}},
+				Ceremonies: []entity.Ceremony{{ID: 1, NewCeremony: entity.NewCeremony{Year: 1998}}},
+			}},
+			respBody: []entity.Award{{
+				ID:         1,
+				NewAward:   entity.NewAward{Name: "Academy Awards"},
+				Categories: []entity.AwardCategory{{ID: 1, NewAwardCategory: entity.NewAwardCategory{Name: "Best Picture"}}},
+				Ceremonies: []entity.Ceremony{{ID: 1, NewCeremony: entity.NewCeremony{Year: 1998}}},
+			}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No awards",
+			respBody: []entity.Award{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "GetAwards error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get awards",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			awardGetterMock := mocks.NewAwardGetter(t)
+			awardGetterMock.On("GetAwards").Return(tt.mockBody, tt.mockError).Once()
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), awardGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Awards)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
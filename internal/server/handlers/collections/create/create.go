@@ -0,0 +1,70 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionCreator
+type CollectionCreator interface {
+	CreateCollection(owner string, collection *entity.NewCollection) (int, error)
+}
+
+type Response struct {
+	resp.Response
+	Collection *entity.Collection `json:"collection"`
+}
+
+// New creates handler creating empty collection owned by current user.
+func New(log *slog.Logger, collectionCreator CollectionCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		var collection entity.NewCollection
+		if err := render.DecodeJSON(r.Body, &collection); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(collection); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		owner := r.Header.Get("x-username")
+
+		id, err := collectionCreator.CreateCollection(owner, &collection)
+		if err != nil {
+			log.Error("Failed to create collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to create collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Collection: &entity.Collection{
+				ID:            id,
+				Owner:         owner,
+				NewCollection: collection,
+				MovieIDs:      []int32{},
+			},
+		})
+	}
+}
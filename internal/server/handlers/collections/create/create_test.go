@@ -0,0 +1,82 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/create"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/create/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		reqCollection *entity.NewCollection
+		respCode      int
+		respError     string
+		mockError     error
+	}{
+		{
+			name:          "Success",
+			reqCollection: &entity.NewCollection{Title: "The Matrix trilogy", Public: true},
+			respCode:      http.StatusOK,
+		},
+		{
+			name:          "Missing title",
+			reqCollection: &entity.NewCollection{},
+			respCode:      http.StatusBadRequest,
+			respError:     "field Title is required",
+		},
+		{
+			name:          "CreateCollection error",
+			reqCollection: &entity.NewCollection{Title: "Staff picks 2026"},
+			respCode:      http.StatusInternalServerError,
+			respError:     "Failed to create collection",
+			mockError:     errors.New("failed to create collection"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionCreatorMock := mocks.NewCollectionCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				collectionCreatorMock.
+					On("CreateCollection", "user", mock.AnythingOfType("*entity.NewCollection")).
+					Return(1, tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), collectionCreatorMock)
+
+			input, err := json.Marshal(tt.reqCollection)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, "user", resp.Collection.Owner)
+			}
+		})
+	}
+}
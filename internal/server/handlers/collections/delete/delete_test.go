@@ -0,0 +1,136 @@
+package delete_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	collectionsDelete "github.com/rmntim/movielab/internal/server/handlers/collections/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionDelete(t *testing.T) {
+	ownCollection := &entity.Collection{ID: 1, Owner: "user"}
+	publicCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test", Public: true}}
+	privateCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test"}}
+
+	tests := []struct {
+		name       string
+		id         string
+		role       string
+		body       any
+		collection *entity.Collection
+		getError   error
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success by owner",
+			id:         "1",
+			body:       nil,
+			collection: ownCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success by admin",
+			id:         "1",
+			role:       "admin",
+			body:       nil,
+			collection: privateCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      nil,
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Collection not found",
+			id:        "1",
+			body:      nil,
+			getError:  storage.ErrCollectionNotFound,
+			respCode:  http.StatusNotFound,
+			respError: "Collection not found",
+		},
+		{
+			name:       "Foreign private collection",
+			id:         "1",
+			body:       nil,
+			collection: privateCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection not found",
+		},
+		{
+			name:       "Foreign public collection",
+			id:         "1",
+			body:       nil,
+			collection: publicCollection,
+			respCode:   http.StatusForbidden,
+			respError:  "Insufficient permissions",
+		},
+		{
+			name:       "DeleteCollection error",
+			id:         "1",
+			body:       nil,
+			collection: ownCollection,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to delete collection",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionDeleterMock := mocks.NewCollectionDeleter(t)
+
+			if tt.collection != nil || tt.getError != nil {
+				collectionDeleterMock.
+					On("GetCollectionById", mock.AnythingOfType("int")).
+					Return(tt.collection, tt.getError).
+					Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				collectionDeleterMock.
+					On("DeleteCollection", mock.AnythingOfType("int")).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := collectionsDelete.New(slogdiscard.NewDiscardLogger(), collectionDeleterMock)
+
+			input, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package get
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionByIdGetter
+type CollectionByIdGetter interface {
+	GetCollectionById(id int) (*entity.Collection, error)
+}
+
+type Response struct {
+	resp.Response
+	Collection *entity.Collection `json:"collection"`
+}
+
+func New(log *slog.Logger, collectionByIdGetter CollectionByIdGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.get.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		collection, err := collectionByIdGetter.GetCollectionById(id)
+		if err != nil {
+			log.Error("Failed to get collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		// Private collections are reported as missing, so their existence isn't leaked
+		if !collection.VisibleTo(r.Header.Get("x-username"), r.Header.Get("x-role")) {
+			status, errResp := resp.StorageError(storage.ErrCollectionNotFound, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response:   resp.Ok(),
+			Collection: collection,
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/get"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/get/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionGet(t *testing.T) {
+	publicCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Public: true}}
+	privateCollection := &entity.Collection{ID: 1, Owner: "other"}
+	ownCollection := &entity.Collection{ID: 1, Owner: "user"}
+
+	tests := []struct {
+		name       string
+		id         string
+		role       string
+		collection *entity.Collection
+		respBody   *entity.Collection
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success public",
+			id:         "1",
+			collection: publicCollection,
+			respBody:   publicCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success own private",
+			id:         "1",
+			collection: ownCollection,
+			respBody:   ownCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success admin",
+			id:         "1",
+			role:       "admin",
+			collection: privateCollection,
+			respBody:   privateCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Foreign private collection",
+			id:         "1",
+			collection: privateCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection not found",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Collection not found",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Collection not found",
+			mockError: storage.ErrCollectionNotFound,
+		},
+		{
+			name:      "GetCollectionById error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get collection",
+			mockError: errors.New("failed to get collection"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionByIdGetterMock := mocks.NewCollectionByIdGetter(t)
+
+			if tt.collection != nil || tt.mockError != nil {
+				collectionByIdGetterMock.
+					On("GetCollectionById", mock.AnythingOfType("int")).
+					Return(tt.collection, tt.mockError).
+					Once()
+			}
+
+			handler := get.New(slogdiscard.NewDiscardLogger(), collectionByIdGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s", tt.id), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp get.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Collection)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Request struct {
+	MovieID int `json:"movie_id" validate:"required"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionMovieAdder
+type CollectionMovieAdder interface {
+	GetCollectionById(id int) (*entity.Collection, error)
+	AddCollectionMovie(id, movieID int) error
+}
+
+// New creates handler appending movie to the end of collection.
+func New(log *slog.Logger, collectionMovieAdder CollectionMovieAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.movies.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		collection, err := collectionMovieAdder.GetCollectionById(id)
+		if err != nil {
+			log.Error("Failed to get collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		username, role := r.Header.Get("x-username"), r.Header.Get("x-role")
+		if !collection.VisibleTo(username, role) {
+			status, errResp := resp.StorageError(storage.ErrCollectionNotFound, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if !collection.EditableBy(username, role) {
+			log.Error("Insufficient permissions", slog.String("username", username))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		if err := collectionMovieAdder.AddCollectionMovie(id, req.MovieID); err != nil {
+			log.Error("Failed to add movie to collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to add movie to collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
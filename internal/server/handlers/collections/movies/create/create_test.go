@@ -0,0 +1,170 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/movies/create"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/movies/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionMovieCreate(t *testing.T) {
+	ownCollection := &entity.Collection{ID: 1, Owner: "user"}
+	publicCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test", Public: true}}
+	privateCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test"}}
+
+	tests := []struct {
+		name       string
+		id         string
+		role       string
+		body       any
+		collection *entity.Collection
+		getError   error
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success by owner",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: ownCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success by admin",
+			id:         "1",
+			role:       "admin",
+			body:       &create.Request{MovieID: 2},
+			collection: privateCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      &create.Request{MovieID: 2},
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Missing movie id",
+			id:        "1",
+			body:      &create.Request{},
+			respCode:  http.StatusBadRequest,
+			respError: "field MovieID is required",
+		},
+		{
+			name:      "Collection not found",
+			id:        "1",
+			body:      &create.Request{MovieID: 2},
+			getError:  storage.ErrCollectionNotFound,
+			respCode:  http.StatusNotFound,
+			respError: "Collection not found",
+		},
+		{
+			name:       "Foreign private collection",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: privateCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection not found",
+		},
+		{
+			name:       "Foreign public collection",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: publicCollection,
+			respCode:   http.StatusForbidden,
+			respError:  "Insufficient permissions",
+		},
+		{
+			name:       "Movie not found",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: ownCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Movie not found",
+			mockError:  storage.ErrMovieNotFound,
+		},
+		{
+			name:       "Collection deleted concurrently",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: ownCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection not found",
+			mockError:  storage.ErrCollectionNotFound,
+		},
+		{
+			name:       "Movie already in collection",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: ownCollection,
+			respCode:   http.StatusConflict,
+			respError:  "Movie is already in collection",
+			mockError:  storage.ErrCollectionEntryExists,
+		},
+		{
+			name:       "AddCollectionMovie error",
+			id:         "1",
+			body:       &create.Request{MovieID: 2},
+			collection: ownCollection,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to add movie to collection",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionMovieAdderMock := mocks.NewCollectionMovieAdder(t)
+
+			if tt.collection != nil || tt.getError != nil {
+				collectionMovieAdderMock.
+					On("GetCollectionById", mock.AnythingOfType("int")).
+					Return(tt.collection, tt.getError).
+					Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				collectionMovieAdderMock.
+					On("AddCollectionMovie", mock.AnythingOfType("int"), 2).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), collectionMovieAdderMock)
+
+			input, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/movies", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/movies", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionMovieRemover
+type CollectionMovieRemover interface {
+	GetCollectionById(id int) (*entity.Collection, error)
+	RemoveCollectionMovie(id, movieID int) error
+}
+
+func New(log *slog.Logger, collectionMovieRemover CollectionMovieRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.movies.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		movieID, err := strconv.Atoi(r.PathValue("movie_id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		collection, err := collectionMovieRemover.GetCollectionById(id)
+		if err != nil {
+			log.Error("Failed to get collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		username, role := r.Header.Get("x-username"), r.Header.Get("x-role")
+		if !collection.VisibleTo(username, role) {
+			status, errResp := resp.StorageError(storage.ErrCollectionNotFound, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if !collection.EditableBy(username, role) {
+			log.Error("Insufficient permissions", slog.String("username", username))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		if err := collectionMovieRemover.RemoveCollectionMovie(id, movieID); err != nil {
+			log.Error("Failed to remove movie from collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove movie from collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,108 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	collectionMoviesDelete "github.com/rmntim/movielab/internal/server/handlers/collections/movies/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/movies/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionMovieDelete(t *testing.T) {
+	ownCollection := &entity.Collection{ID: 1, Owner: "user"}
+	publicCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Public: true}}
+
+	tests := []struct {
+		name       string
+		movieID    string
+		collection *entity.Collection
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success",
+			movieID:    "2",
+			collection: ownCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Bad movie id",
+			movieID:   "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:       "Foreign collection",
+			movieID:    "2",
+			collection: publicCollection,
+			respCode:   http.StatusForbidden,
+			respError:  "Insufficient permissions",
+		},
+		{
+			name:       "Movie not in collection",
+			movieID:    "2",
+			collection: ownCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection entry not found",
+			mockError:  storage.ErrCollectionEntryNotFound,
+		},
+		{
+			name:       "RemoveCollectionMovie error",
+			movieID:    "2",
+			collection: ownCollection,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to remove movie from collection",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionMovieRemoverMock := mocks.NewCollectionMovieRemover(t)
+
+			if tt.collection != nil {
+				collectionMovieRemoverMock.
+					On("GetCollectionById", mock.AnythingOfType("int")).
+					Return(tt.collection, nil).
+					Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				collectionMovieRemoverMock.
+					On("RemoveCollectionMovie", 1, 2).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := collectionMoviesDelete.New(slogdiscard.NewDiscardLogger(), collectionMovieRemoverMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/movies/{movie_id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/1/movies/%s", tt.movieID), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", "user")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
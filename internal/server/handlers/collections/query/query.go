@@ -0,0 +1,74 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionGetter
+type CollectionGetter interface {
+	GetCollections(username string, limit, offset int) ([]entity.Collection, error)
+}
+
+type Response struct {
+	resp.Response
+	Collections []entity.Collection `json:"collections"`
+}
+
+// New creates handler listing public collections and private collections of current user.
+func New(log *slog.Logger, collectionGetter CollectionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		collections, err := collectionGetter.GetCollections(r.Header.Get("x-username"), limit, offset)
+		if err != nil {
+			log.Error("Failed to get collections", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get collections")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if collections == nil {
+			collections = []entity.Collection{}
+		}
+
+		render.JSON(w, r, Response{
+			Response:    resp.Ok(),
+			Collections: collections,
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/query"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/query/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     string
+		offset    string
+		respBody  []entity.Collection
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			limit:    "10",
+			offset:   "0",
+			respBody: []entity.Collection{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			limit:     "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			offset:    "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetCollections error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get collections",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionGetterMock := mocks.NewCollectionGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				collectionGetterMock.
+					On("GetCollections", "user", mock.AnythingOfType("int"), mock.AnythingOfType("int")).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), collectionGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/?limit=%s&offset=%s", tt.limit, tt.offset), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Collections)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package update
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CollectionUpdater
+type CollectionUpdater interface {
+	GetCollectionById(id int) (*entity.Collection, error)
+	UpdateCollection(id int, collection *entity.NewCollection) error
+}
+
+type Response struct {
+	resp.Response
+	Collection *entity.Collection `json:"collection"`
+}
+
+func New(log *slog.Logger, collectionUpdater CollectionUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.collections.update.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		collection, err := collectionUpdater.GetCollectionById(id)
+		if err != nil {
+			log.Error("Failed to get collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		username, role := r.Header.Get("x-username"), r.Header.Get("x-role")
+		if !collection.VisibleTo(username, role) {
+			status, errResp := resp.StorageError(storage.ErrCollectionNotFound, "Failed to get collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if !collection.EditableBy(username, role) {
+			log.Error("Insufficient permissions", slog.String("username", username))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		if err := render.DecodeJSON(r.Body, &collection.NewCollection); err != nil {
+			log.Error("Failed to parse body", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse body"))
+			return
+		}
+
+		if err := validate.Struct(collection.NewCollection); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		if err := collectionUpdater.UpdateCollection(id, &collection.NewCollection); err != nil {
+			log.Error("Failed to update collection", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to update collection")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response:   resp.Ok(),
+			Collection: collection,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/update"
+	"github.com/rmntim/movielab/internal/server/handlers/collections/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollectionUpdate(t *testing.T) {
+	ownCollection := &entity.Collection{ID: 1, Owner: "user"}
+	publicCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test", Public: true}}
+	privateCollection := &entity.Collection{ID: 1, Owner: "other", NewCollection: entity.NewCollection{Title: "Test"}}
+
+	tests := []struct {
+		name       string
+		id         string
+		role       string
+		body       any
+		collection *entity.Collection
+		getError   error
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success by owner",
+			id:         "1",
+			body:       &entity.NewCollection{Title: "Test"},
+			collection: ownCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success by admin",
+			id:         "1",
+			role:       "admin",
+			body:       &entity.NewCollection{Title: "Test"},
+			collection: privateCollection,
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      &entity.NewCollection{Title: "Test"},
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:       "Empty title",
+			id:         "1",
+			body:       map[string]string{"title": ""},
+			collection: ownCollection,
+			respCode:   http.StatusBadRequest,
+			respError:  "field Title is required",
+		},
+		{
+			name:      "Collection not found",
+			id:        "1",
+			body:      &entity.NewCollection{Title: "Test"},
+			getError:  storage.ErrCollectionNotFound,
+			respCode:  http.StatusNotFound,
+			respError: "Collection not found",
+		},
+		{
+			name:       "Foreign private collection",
+			id:         "1",
+			body:       &entity.NewCollection{Title: "Test"},
+			collection: privateCollection,
+			respCode:   http.StatusNotFound,
+			respError:  "Collection not found",
+		},
+		{
+			name:       "Foreign public collection",
+			id:         "1",
+			body:       &entity.NewCollection{Title: "Test"},
+			collection: publicCollection,
+			respCode:   http.StatusForbidden,
+			respError:  "Insufficient permissions",
+		},
+		{
+			name:       "UpdateCollection error",
+			id:         "1",
+			body:       &entity.NewCollection{Title: "Test"},
+			collection: ownCollection,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to update collection",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectionUpdaterMock := mocks.NewCollectionUpdater(t)
+
+			if tt.collection != nil || tt.getError != nil {
+				collectionUpdaterMock.
+					On("GetCollectionById", mock.AnythingOfType("int")).
+					Return(tt.collection, tt.getError).
+					Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				collectionUpdaterMock.
+					On("UpdateCollection", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.NewCollection")).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := update.New(slogdiscard.NewDiscardLogger(), collectionUpdaterMock)
+
+			input, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /{id}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
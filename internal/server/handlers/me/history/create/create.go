@@ -0,0 +1,62 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=HistoryAdder
+type HistoryAdder interface {
+	AddToHistory(username string, entry *entity.NewHistoryEntry) (int, error)
+}
+
+type Response struct {
+	resp.Response
+	ID int `json:"id"`
+}
+
+func New(log *slog.Logger, historyAdder HistoryAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.me.history.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		var entry entity.NewHistoryEntry
+		if err := render.DecodeJSON(r.Body, &entry); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(entry); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		id, err := historyAdder.AddToHistory(r.Header.Get("x-username"), &entry)
+		if err != nil {
+			log.Error("Failed to add movie to history", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to add movie to history")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			ID:       id,
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/me/history/create"
+	"github.com/rmntim/movielab/internal/server/handlers/me/history/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHistoryCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *entity.NewHistoryEntry
+		respID    int
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			req:      &entity.NewHistoryEntry{MovieID: 1, WatchedOn: time.Now()},
+			respID:   1,
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Missing movie id",
+			req:       &entity.NewHistoryEntry{},
+			respCode:  http.StatusBadRequest,
+			respError: "field MovieID is required",
+		},
+		{
+			name:      "Movie not found",
+			req:       &entity.NewHistoryEntry{MovieID: 1},
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "AddToHistory error",
+			req:       &entity.NewHistoryEntry{MovieID: 1},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to add movie to history",
+			mockError: errors.New("failed to add movie to history"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			historyAdderMock := mocks.NewHistoryAdder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				historyAdderMock.
+					On("AddToHistory", "user", mock.AnythingOfType("*entity.NewHistoryEntry")).
+					Return(tt.respID, tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), historyAdderMock)
+
+			input, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respID, resp.ID)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
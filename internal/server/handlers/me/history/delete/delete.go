@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=HistoryRemover
+type HistoryRemover interface {
+	RemoveFromHistory(username string, id int) error
+}
+
+func New(log *slog.Logger, historyRemover HistoryRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.me.history.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		if err := historyRemover.RemoveFromHistory(r.Header.Get("x-username"), id); err != nil {
+			log.Error("Failed to remove history entry", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove history entry")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,86 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	historyDelete "github.com/rmntim/movielab/internal/server/handlers/me/history/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/me/history/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHistoryDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "History entry not found",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "History entry not found",
+			mockError: storage.ErrHistoryEntryNotFound,
+		},
+		{
+			name:      "RemoveFromHistory error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to remove history entry",
+			mockError: errors.New("failed to remove history entry"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			historyRemoverMock := mocks.NewHistoryRemover(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				historyRemoverMock.
+					On("RemoveFromHistory", "user", mock.AnythingOfType("int")).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := historyDelete.New(slogdiscard.NewDiscardLogger(), historyRemoverMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s", tt.id), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=HistoryGetter
+type HistoryGetter interface {
+	GetHistory(username string, limit, offset int, orderBy string, asc bool) ([]entity.HistoryEntry, error)
+}
+
+type Response struct {
+	resp.Response
+	History []entity.HistoryEntry `json:"history"`
+}
+
+var sortFields = map[string]bool{"date": true, "title": true, "release_date": true, "rating": true}
+
+func New(log *slog.Logger, historyGetter HistoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.me.history.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		orderBy := "date"
+		asc := false
+
+		querySort := r.URL.Query().Get("sort")
+		if querySort != "" {
+			// '+' turns into space when query string is decoded
+			if querySort[0] == '+' || querySort[0] == ' ' {
+				asc = true
+			}
+			orderBy = querySort[1:]
+			if !sortFields[orderBy] {
+				log.Error("Invalid sort field", slog.String("sort", querySort))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Invalid sort field"))
+				return
+			}
+		}
+
+		history, err := historyGetter.GetHistory(r.Header.Get("x-username"), limit, offset, orderBy, asc)
+		if err != nil {
+			log.Error("Failed to get history", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get history")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if history == nil {
+			history = []entity.HistoryEntry{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			History:  history,
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/me/history/query"
+	"github.com/rmntim/movielab/internal/server/handlers/me/history/query/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHistoryQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     string
+		offset    string
+		sort      string
+		orderBy   string
+		asc       bool
+		respBody  []entity.HistoryEntry
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success default sort",
+			limit:    "10",
+			offset:   "0",
+			orderBy:  "date",
+			respBody: []entity.HistoryEntry{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Success asc",
+			sort:     "+title",
+			orderBy:  "title",
+			asc:      true,
+			respBody: []entity.HistoryEntry{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Success desc",
+			sort:     "-rating",
+			orderBy:  "rating",
+			respBody: []entity.HistoryEntry{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad sort",
+			sort:      "-password",
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid sort field",
+		},
+		{
+			name:      "Bad limit",
+			limit:     "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			offset:    "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetHistory error",
+			orderBy:   "date",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get history",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			historyGetterMock := mocks.NewHistoryGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				historyGetterMock.
+					On("GetHistory", "user", mock.AnythingOfType("int"), mock.AnythingOfType("int"), tt.orderBy, tt.asc).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), historyGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet,
+				fmt.Sprintf("/?limit=%s&offset=%s&sort=%s", tt.limit, tt.offset, url.QueryEscape(tt.sort)),
+				nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.History)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
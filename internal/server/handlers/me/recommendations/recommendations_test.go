@@ -0,0 +1,100 @@
+package recommendations_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/me/recommendations"
+	"github.com/rmntim/movielab/internal/server/handlers/me/recommendations/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecommendations(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		limit     int
+		offset    int
+		mockBody  []entity.Recommendation
+		respBody  []entity.Recommendation
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:  "Success",
+			limit: 10,
+			mockBody: []entity.Recommendation{
+				{Movie: entity.Movie{ID: 2}, Score: 0.6, Reasons: []entity.RecommendationReason{{Kind: entity.ReasonSharedCast, Score: 0.6, Description: "Also starring A"}}},
+			},
+			respBody: []entity.Recommendation{
+				{Movie: entity.Movie{ID: 2}, Score: 0.6, Reasons: []entity.RecommendationReason{{Kind: entity.ReasonSharedCast, Score: 0.6, Description: "Also starring A"}}},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No recommendations",
+			query:    "limit=5&offset=5",
+			limit:    5,
+			offset:   5,
+			respBody: []entity.Recommendation{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetRecommendations error",
+			limit:     10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get recommendations",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recommendationsGetterMock := mocks.NewRecommendationsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				recommendationsGetterMock.
+					On("GetRecommendations", "user", tt.limit, tt.offset).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := recommendations.New(slogdiscard.NewDiscardLogger(), recommendationsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/?%s", tt.query), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp recommendations.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Recommendations)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	MovieID int `json:"movie_id" validate:"required"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=WatchlistAdder
+type WatchlistAdder interface {
+	AddToWatchlist(username string, movieID int) error
+}
+
+func New(log *slog.Logger, watchlistAdder WatchlistAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.me.watchlist.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		if err := watchlistAdder.AddToWatchlist(r.Header.Get("x-username"), req.MovieID); err != nil {
+			log.Error("Failed to add movie to watchlist", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to add movie to watchlist")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,85 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/me/watchlist/create"
+	"github.com/rmntim/movielab/internal/server/handlers/me/watchlist/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWatchlistCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *create.Request
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			req:      &create.Request{MovieID: 1},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Missing movie id",
+			req:       &create.Request{},
+			respCode:  http.StatusBadRequest,
+			respError: "field MovieID is required",
+		},
+		{
+			name:      "Movie not found",
+			req:       &create.Request{MovieID: 1},
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "AddToWatchlist error",
+			req:       &create.Request{MovieID: 1},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to add movie to watchlist",
+			mockError: errors.New("failed to add movie to watchlist"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			watchlistAdderMock := mocks.NewWatchlistAdder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				watchlistAdderMock.
+					On("AddToWatchlist", "user", tt.req.MovieID).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), watchlistAdderMock)
+
+			input, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=WatchlistRemover
+type WatchlistRemover interface {
+	RemoveFromWatchlist(username string, movieID int) error
+}
+
+func New(log *slog.Logger, watchlistRemover WatchlistRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.me.watchlist.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("movie_id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		if err := watchlistRemover.RemoveFromWatchlist(r.Header.Get("x-username"), movieID); err != nil {
+			log.Error("Failed to remove movie from watchlist", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove movie from watchlist")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -1,17 +1,24 @@
 package create
 
 import (
+	"errors"
+	"fmt"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
+	"slices"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieCreator
 type MovieCreator interface {
-	CreateMovie(movie *entity.NewMovie) (int, error)
+	GetMissingActorIDs(ids []int32) ([]int32, error)
+	CreateMovie(movie *entity.NewMovie, audit entity.AuditInfo) (int, error)
 }
 
 type Response struct {
@@ -40,11 +47,46 @@ func New(log *slog.Logger, movieCreator MovieCreator) http.HandlerFunc {
 			return
 		}
 
-		id, err := movieCreator.CreateMovie(&movie)
+		if err := validate.Struct(movie); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		missing, err := movieCreator.GetMissingActorIDs(movie.ActorIDs)
+		if err != nil {
+			log.Error("Failed to check actors", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to create movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if len(missing) > 0 {
+			log.Error("Actors not found", slog.Any("ids", missing))
+			var fields []resp.FieldError
+			for i, actorID := range movie.ActorIDs {
+				if slices.Contains(missing, actorID) {
+					fields = append(fields, resp.FieldError{
+						Field:   fmt.Sprintf("actor_ids[%d]", i),
+						Rule:    "exists",
+						Message: fmt.Sprintf("actor %d does not exist", actorID),
+					})
+				}
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.InvalidFields(fields))
+			return
+		}
+
+		id, err := movieCreator.CreateMovie(&movie, audit.FromRequest(r))
 		if err != nil {
 			log.Error("Failed to create movie", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to create movie"))
+			status, errResp := resp.StorageError(err, "Failed to create movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/create"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -24,6 +28,9 @@ func TestMovieCreate(t *testing.T) {
 		respCode  int
 		respError string
 		mockError error
+		// missing is returned by GetMissingActorIDs
+		missing    []int32
+		respFields []resp.FieldError
 	}{
 		{
 			name: "Success",
@@ -36,6 +43,101 @@ func TestMovieCreate(t *testing.T) {
 			},
 			respCode: http.StatusOK,
 		},
+		{
+			name: "Success with metadata",
+			reqMovie: &entity.NewMovie{
+				Title:            "Test",
+				ReleaseDate:      time.Now(),
+				Rating:           1,
+				Runtime:          120,
+				Countries:        []string{"US", "GB"},
+				OriginalLanguage: "en",
+				Certifications:   map[string]string{"US": "PG-13"},
+				Budget:           &entity.Money{Amount: 1000000, Currency: "USD"},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name: "Invalid metadata",
+			reqMovie: &entity.NewMovie{
+				Title:       "Test",
+				ReleaseDate: time.Now(),
+				Rating:      1,
+				Countries:   []string{"USA"},
+				BoxOffice:   &entity.Money{Amount: 1000000},
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field Countries[0] is invalid, field Currency is required",
+			respFields: []resp.FieldError{
+				{Field: "countries[0]", Rule: "iso3166_1_alpha2", Message: "is invalid"},
+				{Field: "box_office.currency", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "Out of range",
+			reqMovie: &entity.NewMovie{
+				Title:       strings.Repeat("a", 151),
+				ReleaseDate: time.Now(),
+				Rating:      42,
+				ActorIDs:    []int32{1, 0},
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field Title is invalid, field Rating is invalid, field ActorIDs[1] is invalid",
+			respFields: []resp.FieldError{
+				{Field: "title", Rule: "max", Param: "150", Message: "must be at most 150 characters"},
+				{Field: "rating", Rule: "max", Param: "10", Message: "must be at most 10"},
+				{Field: "actor_ids[1]", Rule: "gt", Param: "0", Message: "must be greater than 0"},
+			},
+		},
+		{
+			name: "Missing actor",
+			reqMovie: &entity.NewMovie{
+				Title:       "Test",
+				ReleaseDate: time.Now(),
+				Rating:      1,
+				ActorIDs:    []int32{1, 5},
+			},
+			missing:   []int32{5},
+			respCode:  http.StatusBadRequest,
+			respError: "field actor_ids[1] is invalid",
+			respFields: []resp.FieldError{
+				{Field: "actor_ids[1]", Rule: "exists", Message: "actor 5 does not exist"},
+			},
+		},
+		{
+			name: "Duplicate actors",
+			reqMovie: &entity.NewMovie{
+				Title:       "Test",
+				ReleaseDate: time.Now(),
+				Rating:      1,
+				ActorIDs:    []int32{1, 1},
+			},
+			respCode:  http.StatusBadRequest,
+			respError: "field ActorIDs is invalid",
+			respFields: []resp.FieldError{
+				{Field: "actor_ids", Rule: "unique", Message: "must not contain duplicates"},
+			},
+		},
+		{
+			name: "Actor deleted meanwhile",
+			reqMovie: &entity.NewMovie{
+				Title:       "Test",
+				ReleaseDate: time.Now(),
+				Rating:      1,
+				ActorIDs:    []int32{5},
+			},
+			respCode:  http.StatusUnprocessableEntity,
+			respError: "field actor_ids is invalid",
+			respFields: []resp.FieldError{
+				{Field: "actor_ids", Rule: "exists", Message: "record 5 does not exist"},
+			},
+			mockError: fmt.Errorf("storage.postgres.CreateMovie: %w", &storage.ConstraintError{
+				Err:        storage.ErrReferenceNotFound,
+				Constraint: "movie_actors_actor_id_fkey",
+				Field:      "actor_ids",
+				Value:      "5",
+			}),
+		},
 		{
 			name:      "Unauthorized",
 			role:      "user",
@@ -64,8 +166,14 @@ func TestMovieCreate(t *testing.T) {
 
 			movieCreatorMock := mocks.NewMovieCreator(t)
 
-			if tt.respError == "" || tt.mockError != nil {
-				movieCreatorMock.On("CreateMovie", mock.AnythingOfType("*entity.NewMovie")).Return(1, tt.mockError).Once()
+			missing := tt.missing
+			if missing == nil {
+				missing = []int32{}
+			}
+			movieCreatorMock.On("GetMissingActorIDs", mock.AnythingOfType("[]int32")).Return(missing, nil).Maybe()
+
+			if len(tt.missing) == 0 && (tt.respError == "" || tt.mockError != nil) {
+				movieCreatorMock.On("CreateMovie", mock.AnythingOfType("*entity.NewMovie"), entity.AuditInfo{Username: "admin", RequestID: "req-1"}).Return(1, tt.mockError).Once()
 			}
 
 			handler := create.New(slogdiscard.NewDiscardLogger(), movieCreatorMock)
@@ -84,6 +192,8 @@ func TestMovieCreate(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
@@ -93,6 +203,7 @@ func TestMovieCreate(t *testing.T) {
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
 			require.Equal(t, tt.respError, resp.Error)
+			require.Equal(t, tt.respFields, resp.Fields)
 		})
 	}
 }
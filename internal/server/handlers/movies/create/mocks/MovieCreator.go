@@ -12,9 +12,9 @@ type MovieCreator struct {
 	mock.Mock
 }
 
-// CreateMovie provides a mock function with given fields: movie
-func (_m *MovieCreator) CreateMovie(movie *entity.NewMovie) (int, error) {
-	ret := _m.Called(movie)
+// CreateMovie provides a mock function with given fields: movie, audit
+func (_m *MovieCreator) CreateMovie(movie *entity.NewMovie, audit entity.AuditInfo) (int, error) {
+	ret := _m.Called(movie, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateMovie")
@@ -22,17 +22,47 @@ func (_m *MovieCreator) CreateMovie(movie *entity.NewMovie) (int, error) {
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*entity.NewMovie) (int, error)); ok {
-		return rf(movie)
+	if rf, ok := ret.Get(0).(func(*entity.NewMovie, entity.AuditInfo) (int, error)); ok {
+		return rf(movie, audit)
 	}
-	if rf, ok := ret.Get(0).(func(*entity.NewMovie) int); ok {
-		r0 = rf(movie)
+	if rf, ok := ret.Get(0).(func(*entity.NewMovie, entity.AuditInfo) int); ok {
+		r0 = rf(movie, audit)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(*entity.NewMovie) error); ok {
-		r1 = rf(movie)
+	if rf, ok := ret.Get(1).(func(*entity.NewMovie, entity.AuditInfo) error); ok {
+		r1 = rf(movie, audit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMissingActorIDs provides a mock function with given fields: ids
+func (_m *MovieCreator) GetMissingActorIDs(ids []int32) ([]int32, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissingActorIDs")
+	}
+
+	var r0 []int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int32) ([]int32, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int32) []int32); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int32) error); ok {
+		r1 = rf(ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,132 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/crew/create"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/crew/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrewCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		reqMember *entity.CrewMember
+		role      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:      "Success",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleDirector},
+			respCode:  http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleDirector},
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Bad role",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: "gaffer"},
+			respCode:  http.StatusBadRequest,
+			respError: "field Role is invalid",
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleDirector},
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "Person not found",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleDirector},
+			respCode:  http.StatusNotFound,
+			respError: "Person not found",
+			mockError: storage.ErrPersonNotFound,
+		},
+		{
+			name:      "Duplicate credit",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleDirector},
+			respCode:  http.StatusConflict,
+			respError: "Credit already exists",
+			mockError: storage.ErrCreditExists,
+		},
+		{
+			name:      "AddCrewMember error",
+			id:        "1",
+			reqMember: &entity.CrewMember{PersonID: 1, Role: entity.CrewRoleComposer},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to add crew member",
+			mockError: errors.New("failed to add crew member"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			crewMemberAdderMock := mocks.NewCrewMemberAdder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				crewMemberAdderMock.
+					On("AddCrewMember", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.CrewMember")).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), crewMemberAdderMock)
+
+			input, err := json.Marshal(tt.reqMember)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/crew", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/crew", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CrewMemberRemover
+type CrewMemberRemover interface {
+	RemoveCrewMember(movieID int, member *entity.CrewMember) error
+}
+
+func New(log *slog.Logger, crewMemberRemover CrewMemberRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.crew.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		personID, err := strconv.Atoi(r.PathValue("person_id"))
+		if err != nil {
+			log.Error("Failed to parse person id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse person id"))
+			return
+		}
+
+		member := entity.CrewMember{
+			PersonID: int32(personID),
+			Role:     r.PathValue("role"),
+		}
+
+		if err := crewMemberRemover.RemoveCrewMember(movieID, &member); err != nil {
+			log.Error("Failed to remove crew member", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to remove crew member")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
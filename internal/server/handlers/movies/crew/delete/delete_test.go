@@ -0,0 +1,112 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	crewDelete "github.com/rmntim/movielab/internal/server/handlers/movies/crew/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/crew/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrewDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		personID  string
+		role      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			personID: "1",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			personID:  "1",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Bad person id",
+			id:        "1",
+			personID:  "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse person id",
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			personID:  "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Credit not found",
+			id:        "1",
+			personID:  "1",
+			respCode:  http.StatusNotFound,
+			respError: "Credit not found",
+			mockError: storage.ErrCreditNotFound,
+		},
+		{
+			name:      "RemoveCrewMember error",
+			id:        "1",
+			personID:  "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to remove crew member",
+			mockError: errors.New("failed to remove crew member"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			crewMemberRemoverMock := mocks.NewCrewMemberRemover(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				crewMemberRemoverMock.
+					On("RemoveCrewMember", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.CrewMember")).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := crewDelete.New(slogdiscard.NewDiscardLogger(), crewMemberRemoverMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/crew/{person_id}/{role}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s/crew/%s/director", tt.id, tt.personID), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package delete
 
 import (
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/prefer"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,10 +17,14 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieDeleter
 type MovieDeleter interface {
-	DeleteMovie(id int) error
+	GetMovieById(id int, langs []string) (*entity.Movie, error)
+	// DeleteMovie only deletes movie of non-zero version if it is still current
+	DeleteMovie(id, version int, audit entity.AuditInfo) error
 }
 
-func New(log *slog.Logger, movieDeleter MovieDeleter) http.HandlerFunc {
+// New deletes movie, If-Match header is checked against ETag of the movie when present.
+// Missing movie is 404, unless client prefers idempotent delete, which responds 204 either way.
+func New(log *slog.Logger, movieDeleter MovieDeleter, requireIfMatch bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movies.delete.New"
 
@@ -35,11 +45,62 @@ func New(log *slog.Logger, movieDeleter MovieDeleter) http.HandlerFunc {
 			return
 		}
 
-		err = movieDeleter.DeleteMovie(id)
+		// Zero version deletes movie regardless of its version
+		version := 0
+		ifMatch := r.Header.Get("If-Match")
+		if ifMatch == "" && requireIfMatch {
+			log.Error("Missing If-Match header")
+			w.WriteHeader(http.StatusPreconditionRequired)
+			render.JSON(w, r, resp.Error("If-Match header is required"))
+			return
+		}
+		if ifMatch != "" {
+			current, err := movieDeleter.GetMovieById(id, nil)
+			if err != nil && !errors.Is(err, storage.ErrMovieNotFound) {
+				log.Error("Failed to get movie", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to delete movie")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+			// Gone movie has no current ETag to match
+			if err != nil || !etag.Match(ifMatch, current.Version) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Movie was modified"))
+				return
+			}
+			version = current.Version
+		}
+
+		// Idempotent delete succeeds for missing movie, unless its version was expected
+		idempotent := prefer.Has(r, prefer.Idempotent)
+
+		err = movieDeleter.DeleteMovie(id, version, audit.FromRequest(r))
+		if errors.Is(err, storage.ErrMovieNotFound) && version == 0 {
+			if !idempotent {
+				status, errResp := resp.StorageError(err, "Failed to delete movie")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+			err = nil
+		}
 		if err != nil {
+			if errors.Is(err, storage.ErrVersionConflict) || errors.Is(err, storage.ErrMovieNotFound) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Movie was modified"))
+				return
+			}
 			log.Error("Failed to delete movie", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to delete movie"))
+			status, errResp := resp.StorageError(err, "Failed to delete movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if idempotent {
+			w.Header().Set(prefer.AppliedHeader, prefer.Idempotent)
+			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
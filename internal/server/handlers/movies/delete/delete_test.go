@@ -1,11 +1,15 @@
 package delete_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	moviesDelete "github.com/rmntim/movielab/internal/server/handlers/movies/delete"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -21,12 +25,88 @@ func TestMoviesDelete(t *testing.T) {
 		respCode  int
 		respError string
 		mockError error
+		ifMatch   string
+		// requireIfMatch is passed to handler
+		requireIfMatch bool
+		getError       error
+		// version is expected by DeleteMovie, current version of the movie is 3
+		version int
+		// prefer is sent in Prefer header
+		prefer string
 	}{
 		{
 			name:     "Success",
 			id:       "1",
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "Success with If-Match",
+			id:       "1",
+			ifMatch:  `"3"`,
+			version:  3,
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Missing movie",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:     "Idempotent delete",
+			id:       "1",
+			prefer:   "idempotent",
+			respCode: http.StatusNoContent,
+		},
+		{
+			name:      "Idempotent delete of missing movie",
+			id:        "1",
+			prefer:    "return=minimal, idempotent",
+			respCode:  http.StatusNoContent,
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "Idempotent delete of movie deleted meanwhile",
+			id:        "1",
+			ifMatch:   `"3"`,
+			version:   3,
+			prefer:    "idempotent",
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "If-Match mismatch",
+			id:        "1",
+			ifMatch:   `"2"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+		},
+		{
+			name:      "If-Match of missing movie",
+			id:        "1",
+			ifMatch:   `"3"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+			getError:  storage.ErrMovieNotFound,
+		},
+		{
+			name:      "Concurrent update",
+			id:        "1",
+			ifMatch:   "*",
+			version:   3,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+			mockError: storage.ErrVersionConflict,
+		},
+		{
+			name:           "If-Match required",
+			id:             "1",
+			requireIfMatch: true,
+			respCode:       http.StatusPreconditionRequired,
+			respError:      "If-Match header is required",
+		},
 		{
 			name:      "Bad id",
 			id:        "a",
@@ -56,14 +136,20 @@ func TestMoviesDelete(t *testing.T) {
 
 			moviesDeleterMock := mocks.NewMovieDeleter(t)
 
+			if tt.ifMatch != "" {
+				moviesDeleterMock.
+					On("GetMovieById", 1, []string(nil)).
+					Return(&entity.Movie{Version: 3}, tt.getError).
+					Once()
+			}
 			if tt.respError == "" || tt.mockError != nil {
 				moviesDeleterMock.
-					On("DeleteMovie", mock.AnythingOfType("int")).
+					On("DeleteMovie", mock.AnythingOfType("int"), tt.version, entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
 					Return(tt.mockError).
 					Once()
 			}
 
-			handler := moviesDelete.New(slogdiscard.NewDiscardLogger(), moviesDeleterMock)
+			handler := moviesDelete.New(slogdiscard.NewDiscardLogger(), moviesDeleterMock, tt.requireIfMatch)
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("DELETE /{id}", handler)
@@ -76,11 +162,27 @@ func TestMoviesDelete(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			if tt.prefer != "" {
+				req.Header.Set("Prefer", tt.prefer)
+			}
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.respCode == http.StatusNoContent {
+				require.Equal(t, "idempotent", rr.Header().Get("Preference-Applied"))
+				require.Empty(t, rr.Body.String())
+				return
+			}
+			var errResp resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+			require.Equal(t, tt.respError, errResp.Error)
 		})
 	}
 }
@@ -2,24 +2,27 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	entity "github.com/rmntim/movielab/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MovieDeleter is an autogenerated mock type for the MovieDeleter type
 type MovieDeleter struct {
 	mock.Mock
 }
 
-// DeleteMovie provides a mock function with given fields: id
-func (_m *MovieDeleter) DeleteMovie(id int) error {
-	ret := _m.Called(id)
+// DeleteMovie provides a mock function with given fields: id, version, audit
+func (_m *MovieDeleter) DeleteMovie(id int, version int, audit entity.AuditInfo) error {
+	ret := _m.Called(id, version, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, int, entity.AuditInfo) error); ok {
+		r0 = rf(id, version, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,6 +30,36 @@ func (_m *MovieDeleter) DeleteMovie(id int) error {
 	return r0
 }
 
+// GetMovieById provides a mock function with given fields: id, langs
+func (_m *MovieDeleter) GetMovieById(id int, langs []string) (*entity.Movie, error) {
+	ret := _m.Called(id, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieById")
+	}
+
+	var r0 *entity.Movie
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Movie, error)); ok {
+		return rf(id, langs)
+	}
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Movie); ok {
+		r0 = rf(id, langs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Movie)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMovieDeleter creates a new instance of MovieDeleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMovieDeleter(t interface {
@@ -1,20 +1,25 @@
 package get
 
 import (
-	"errors"
 	"github.com/go-chi/render"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/fieldset"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
-	"github.com/rmntim/movielab/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieByIdGetter
 type MovieByIdGetter interface {
-	GetMovieById(id int) (*entity.Movie, error)
+	GetMovieById(id int, langs []string) (*entity.Movie, error)
+	GetMovieAsOf(id int, asOf time.Time) (*entity.Movie, error)
+	FlagWatchlisted(username string, movies []entity.Movie) error
+	EmbedActors(movies []entity.Movie, langs []string) error
 }
 
 type Response struct {
@@ -22,6 +27,8 @@ type Response struct {
 	Movie *entity.Movie `json:"movie"`
 }
 
+// New returns movie, or its past version when `as_of` timestamp is given.
+// Its actors are embedded with `include=actors`.
 func New(log *slog.Logger, movieByIdGetter MovieByIdGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movies.get.New"
@@ -36,22 +43,79 @@ func New(log *slog.Logger, movieByIdGetter MovieByIdGetter) http.HandlerFunc {
 			return
 		}
 
-		movie, err := movieByIdGetter.GetMovieById(id)
+		include, err := fieldset.Include(r.URL.Query(), "actors")
 		if err != nil {
-			if errors.Is(err, storage.ErrMovieNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("Movie not found"))
+			log.Error("Failed to parse include", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse include"))
+			return
+		}
+
+		var movie *entity.Movie
+		if asOf := r.URL.Query().Get("as_of"); asOf != "" {
+			var at time.Time
+			at, err = time.Parse(time.RFC3339, asOf)
+			if err != nil {
+				log.Error("Failed to parse as_of", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse as_of"))
 				return
 			}
+			// Past versions are not translated
+			movie, err = movieByIdGetter.GetMovieAsOf(id, at)
+		} else {
+			// Translated fields depend on requested language
+			w.Header().Add("Vary", "Accept-Language")
+
+			movie, err = movieByIdGetter.GetMovieById(id, locale.FromRequest(r))
+		}
+		if err != nil {
 			log.Error("Failed to get movie", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get movie"))
+			status, errResp := resp.StorageError(err, "Failed to get movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
-		render.JSON(w, r, Response{
+		movies := []entity.Movie{*movie}
+		if err := movieByIdGetter.FlagWatchlisted(r.Header.Get("x-username"), movies); err != nil {
+			log.Error("Failed to check watchlist", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if include["actors"] {
+			if err := movieByIdGetter.EmbedActors(movies, locale.FromRequest(r)); err != nil {
+				log.Error("Failed to get actors", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to get movie")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+		}
+		movie = &movies[0]
+
+		response := Response{
 			Response: resp.Ok(),
 			Movie:    movie,
-		})
+		}
+
+		// Past versions can't be updated, so only current one is tagged
+		if r.URL.Query().Get("as_of") == "" {
+			tag, err := etag.Tag(movie.Version, response)
+			if err != nil {
+				log.Error("Failed to tag movie", sl.Err(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("Failed to get movie"))
+				return
+			}
+			if etag.NotModified(w, r, tag, movie.LastModified()) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		render.JSON(w, r, response)
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/get"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/get/mocks"
@@ -14,16 +16,25 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestMoviesGet(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := &entity.Movie{Version: 3, UpdatedAt: updatedAt}
+
 	tests := []struct {
-		name      string
-		id        string
-		respBody  *entity.Movie
-		respCode  int
-		respError string
-		mockError error
+		name string
+		id   string
+		asOf string
+		// ifNoneMatch is sent as If-None-Match, "current" stands for tag of the returned movie
+		ifNoneMatch     string
+		ifModifiedSince string
+		respBody        *entity.Movie
+		respCode        int
+		respError       string
+		mockError       error
+		flagError       error
 	}{
 		{
 			name:     "Success",
@@ -31,6 +42,54 @@ func TestMoviesGet(t *testing.T) {
 			respBody: &entity.Movie{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "Success as of",
+			id:       "1",
+			asOf:     "2024-01-01T00:00:00Z",
+			respBody: &entity.Movie{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:        "Not modified",
+			id:          "1",
+			ifNoneMatch: "current",
+			respCode:    http.StatusNotModified,
+		},
+		{
+			name:        "Modified",
+			id:          "1",
+			ifNoneMatch: `"2-0000000000000000"`,
+			respBody:    &entity.Movie{},
+			respCode:    http.StatusOK,
+		},
+		{
+			name:            "Not modified since",
+			id:              "1",
+			ifModifiedSince: "Mon, 01 Jan 2024 00:00:00 GMT",
+			respCode:        http.StatusNotModified,
+		},
+		{
+			name:            "Modified since",
+			id:              "1",
+			ifModifiedSince: "Sun, 31 Dec 2023 00:00:00 GMT",
+			respBody:        &entity.Movie{},
+			respCode:        http.StatusOK,
+		},
+		{
+			name:      "Bad as of",
+			id:        "1",
+			asOf:      "yesterday",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse as_of",
+		},
+		{
+			name:      "Movie not found as of",
+			id:        "1",
+			asOf:      "2024-01-01T00:00:00Z",
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
 		{
 			name:      "Bad id",
 			id:        "a",
@@ -51,6 +110,13 @@ func TestMoviesGet(t *testing.T) {
 			respError: "Movie not found",
 			mockError: storage.ErrMovieNotFound,
 		},
+		{
+			name:      "FlagWatchlisted error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get movie",
+			flagError: errors.New("failed to check watchlist"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -61,10 +127,23 @@ func TestMoviesGet(t *testing.T) {
 
 			moviesByIdGetterMock := mocks.NewMovieByIdGetter(t)
 
-			if tt.respError == "" || tt.mockError != nil {
+			if tt.respError == "" || tt.mockError != nil || tt.flagError != nil {
+				if tt.asOf != "" {
+					moviesByIdGetterMock.
+						On("GetMovieAsOf", 1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+						Return(&entity.Movie{}, tt.mockError).
+						Once()
+				} else {
+					moviesByIdGetterMock.
+						On("GetMovieById", mock.AnythingOfType("int"), []string{"fr-ca", "fr"}).
+						Return(current, tt.mockError).
+						Once()
+				}
+			}
+			if tt.respError == "" || tt.flagError != nil {
 				moviesByIdGetterMock.
-					On("GetMovieById", mock.AnythingOfType("int")).
-					Return(tt.respBody, tt.mockError).
+					On("FlagWatchlisted", "user", mock.AnythingOfType("[]entity.Movie")).
+					Return(tt.flagError).
 					Once()
 			}
 
@@ -73,13 +152,136 @@ func TestMoviesGet(t *testing.T) {
 			mux := http.NewServeMux()
 			mux.HandleFunc("/{id}", handler)
 
-			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s", tt.id), nil)
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s?as_of=%s", tt.id, tt.asOf), nil)
 			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "fr-CA, en;q=0")
+			req.Header.Set("x-username", "user")
+			if tt.ifNoneMatch == "current" {
+				tag, err := etag.Tag(current.Version, get.Response{Response: resp.Ok(), Movie: current})
+				require.NoError(t, err)
+				req.Header.Set("If-None-Match", tag)
+			} else if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
 
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.asOf == "" && tt.respError == "" {
+				require.Regexp(t, `^"3-[0-9a-f]+"$`, rr.Header().Get("ETag"))
+				require.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", rr.Header().Get("Last-Modified"))
+			}
+			if rr.Code == http.StatusNotModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
+
+			var resp get.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Movie)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
+
+func TestMoviesGetInclude(t *testing.T) {
+	actors := []entity.Actor{{ID: 2, NewActor: entity.NewActor{Name: "Al Pacino"}, UpdatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	// rendered are actors as they are sent, without update time
+	rendered := []entity.Actor{{ID: 2, NewActor: entity.NewActor{Name: "Al Pacino"}}}
+
+	tests := []struct {
+		name       string
+		include    string
+		respBody   *entity.Movie
+		respCode   int
+		respError  string
+		embedError error
+		// noActors makes embedding find no actors of the movie
+		noActors     bool
+		lastModified string
+	}{
+		{
+			// Actor changed after the movie, so the cached copy is stale
+			name:         "Include actors",
+			include:      "actors",
+			respBody:     &entity.Movie{ID: 1, NewMovie: entity.NewMovie{ActorIDs: []int32{2}}, Actors: &rendered},
+			respCode:     http.StatusOK,
+			lastModified: "Tue, 02 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:         "Include actors of movie without actors",
+			include:      "actors",
+			noActors:     true,
+			respCode:     http.StatusNotModified,
+			lastModified: "Mon, 01 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:      "Unknown include",
+			include:   "reviews",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse include",
+		},
+		{
+			name:       "EmbedActors error",
+			include:    "actors",
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get movie",
+			embedError: errors.New("failed to get actors"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			moviesByIdGetterMock := mocks.NewMovieByIdGetter(t)
+			moviesByIdGetterMock.
+				On("GetMovieById", 1, []string{"en"}).
+				Return(&entity.Movie{ID: 1, NewMovie: entity.NewMovie{ActorIDs: []int32{2}}, Version: 3, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil).
+				Maybe()
+			moviesByIdGetterMock.
+				On("FlagWatchlisted", "", mock.AnythingOfType("[]entity.Movie")).
+				Return(nil).
+				Maybe()
+			moviesByIdGetterMock.
+				On("EmbedActors", mock.AnythingOfType("[]entity.Movie"), []string{"en"}).
+				Run(func(args mock.Arguments) {
+					embedded := actors
+					if tt.noActors {
+						embedded = []entity.Actor{}
+					}
+					args.Get(0).([]entity.Movie)[0].Actors = &embedded
+				}).
+				Return(tt.embedError).
+				Maybe()
+
+			handler := get.New(slogdiscard.NewDiscardLogger(), moviesByIdGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}", handler)
+
+			req, err := http.NewRequest(http.MethodGet, "/1?include="+tt.include, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+			req.Header.Set("If-Modified-Since", "Mon, 01 Jan 2024 00:00:00 GMT")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			require.Equal(t, tt.lastModified, rr.Header().Get("Last-Modified"))
+			if rr.Code == http.StatusNotModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
+
 			var resp get.Response
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
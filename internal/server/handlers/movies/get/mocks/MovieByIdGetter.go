@@ -6,6 +6,8 @@ import (
 	entity "github.com/rmntim/movielab/internal/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MovieByIdGetter is an autogenerated mock type for the MovieByIdGetter type
@@ -13,9 +15,75 @@ type MovieByIdGetter struct {
 	mock.Mock
 }
 
-// GetMovieById provides a mock function with given fields: id
-func (_m *MovieByIdGetter) GetMovieById(id int) (*entity.Movie, error) {
-	ret := _m.Called(id)
+// EmbedActors provides a mock function with given fields: movies, langs
+func (_m *MovieByIdGetter) EmbedActors(movies []entity.Movie, langs []string) error {
+	ret := _m.Called(movies, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmbedActors")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]entity.Movie, []string) error); ok {
+		r0 = rf(movies, langs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlagWatchlisted provides a mock function with given fields: username, movies
+func (_m *MovieByIdGetter) FlagWatchlisted(username string, movies []entity.Movie) error {
+	ret := _m.Called(username, movies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagWatchlisted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []entity.Movie) error); ok {
+		r0 = rf(username, movies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMovieAsOf provides a mock function with given fields: id, asOf
+func (_m *MovieByIdGetter) GetMovieAsOf(id int, asOf time.Time) (*entity.Movie, error) {
+	ret := _m.Called(id, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieAsOf")
+	}
+
+	var r0 *entity.Movie
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) (*entity.Movie, error)); ok {
+		return rf(id, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Time) *entity.Movie); ok {
+		r0 = rf(id, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Movie)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Time) error); ok {
+		r1 = rf(id, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieById provides a mock function with given fields: id, langs
+func (_m *MovieByIdGetter) GetMovieById(id int, langs []string) (*entity.Movie, error) {
+	ret := _m.Called(id, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMovieById")
@@ -23,19 +91,19 @@ func (_m *MovieByIdGetter) GetMovieById(id int) (*entity.Movie, error) {
 
 	var r0 *entity.Movie
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*entity.Movie, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Movie, error)); ok {
+		return rf(id, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int) *entity.Movie); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Movie); ok {
+		r0 = rf(id, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Movie)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,62 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/imaging"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=PosterSetter
+type PosterSetter interface {
+	SetMoviePoster(id int, image *entity.Image) (*entity.Image, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ImageDeleter
+type ImageDeleter interface {
+	Delete(prefix string) error
+}
+
+func New(log *slog.Logger, posterSetter PosterSetter, imageDeleter ImageDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.poster.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		if _, err := posterSetter.SetMoviePoster(id, nil); err != nil {
+			log.Error("Failed to delete poster", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to delete poster")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if err := imageDeleter.Delete(imaging.MoviePosterPrefix(id)); err != nil {
+			log.Error("Failed to delete poster", sl.Err(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("Failed to delete poster"))
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
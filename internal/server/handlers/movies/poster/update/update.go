@@ -0,0 +1,119 @@
+package update
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/imaging"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=PosterSetter
+type PosterSetter interface {
+	SetMoviePoster(id int, image *entity.Image) (*entity.Image, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ImageUploader
+type ImageUploader interface {
+	Upload(prefix string, data []byte) (*entity.Image, error)
+	Discard(image *entity.Image) error
+}
+
+type Response struct {
+	resp.Response
+	Image *entity.Image `json:"image,omitempty"`
+}
+
+// New accepts multipart form with poster in `image` field, requests larger than maxSize bytes are rejected.
+func New(log *slog.Logger, posterSetter PosterSetter, imageUploader ImageUploader, maxSize int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.poster.update.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+		file, _, err := r.FormFile("image")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, resp.Error("Image is too large"))
+				return
+			}
+			log.Error("Failed to parse form", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse form"))
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			log.Error("Failed to read image", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to read image"))
+			return
+		}
+
+		prefix := imaging.MoviePosterPrefix(id)
+		image, err := imageUploader.Upload(prefix, data)
+		if err != nil {
+			if errors.Is(err, imaging.ErrUnsupportedType) {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				render.JSON(w, r, resp.Error("Unsupported image type"))
+				return
+			}
+			if errors.Is(err, imaging.ErrInvalidImage) {
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Invalid image"))
+				return
+			}
+			log.Error("Failed to upload poster", sl.Err(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("Failed to upload poster"))
+			return
+		}
+
+		// Replaced poster is removed only after the new one is saved, so stored poster never points to missing files
+		previous, err := posterSetter.SetMoviePoster(id, image)
+		if err != nil {
+			if err := imageUploader.Discard(image); err != nil {
+				log.Error("Failed to delete orphaned poster", sl.Err(err))
+			}
+			log.Error("Failed to update poster", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to update poster")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if err := imageUploader.Discard(previous); err != nil {
+			log.Error("Failed to delete replaced poster", sl.Err(err))
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Image:    image,
+		})
+	}
+}
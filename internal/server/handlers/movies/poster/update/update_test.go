@@ -0,0 +1,195 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/imaging"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	posterUpdate "github.com/rmntim/movielab/internal/server/handlers/movies/poster/update"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/poster/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const maxSize = 1024
+
+func TestPosterUpdate(t *testing.T) {
+	image := &entity.Image{URL: "/images/movies/1/poster/v2/original", Width: 10, Height: 15, Prefix: "movies/1/poster/v2"}
+	previous := &entity.Image{URL: "/images/movies/1/poster/v1/original", Prefix: "movies/1/poster/v1"}
+
+	tests := []struct {
+		name        string
+		role        string
+		field       string
+		data        []byte
+		respCode    int
+		respError   string
+		uploadError error
+		setterError error
+		// previous is image replaced by the upload
+		previous *entity.Image
+	}{
+		{
+			name:     "Success",
+			role:     "admin",
+			field:    "image",
+			data:     []byte("image"),
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Success replacing image",
+			role:     "admin",
+			field:    "image",
+			data:     []byte("image"),
+			respCode: http.StatusOK,
+			previous: previous,
+		},
+		{
+			name:      "Not admin",
+			role:      "user",
+			field:     "image",
+			data:      []byte("image"),
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Missing image",
+			role:      "admin",
+			field:     "file",
+			data:      []byte("image"),
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse form",
+		},
+		{
+			name:      "Too large",
+			role:      "admin",
+			field:     "image",
+			data:      bytes.Repeat([]byte{0}, 2*maxSize),
+			respCode:  http.StatusRequestEntityTooLarge,
+			respError: "Image is too large",
+		},
+		{
+			name:        "Unsupported type",
+			role:        "admin",
+			field:       "image",
+			data:        []byte("image"),
+			respCode:    http.StatusUnsupportedMediaType,
+			respError:   "Unsupported image type",
+			uploadError: imaging.ErrUnsupportedType,
+		},
+		{
+			name:        "Invalid image",
+			role:        "admin",
+			field:       "image",
+			data:        []byte("image"),
+			respCode:    http.StatusBadRequest,
+			respError:   "Invalid image",
+			uploadError: imaging.ErrInvalidImage,
+		},
+		{
+			name:        "Upload error",
+			role:        "admin",
+			field:       "image",
+			data:        []byte("image"),
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to upload poster",
+			uploadError: errors.New("unexpected error"),
+		},
+		{
+			name:        "Movie not found",
+			role:        "admin",
+			field:       "image",
+			data:        []byte("image"),
+			respCode:    http.StatusNotFound,
+			respError:   "Movie not found",
+			setterError: storage.ErrMovieNotFound,
+		},
+		{
+			name:        "SetMoviePoster error",
+			role:        "admin",
+			field:       "image",
+			data:        []byte("image"),
+			respCode:    http.StatusInternalServerError,
+			respError:   "Failed to update poster",
+			setterError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			posterSetterMock := mocks.NewPosterSetter(t)
+			imageUploaderMock := mocks.NewImageUploader(t)
+
+			if tt.respError == "" || tt.uploadError != nil || tt.setterError != nil {
+				uploaded := image
+				if tt.uploadError != nil {
+					uploaded = nil
+				}
+				imageUploaderMock.
+					On("Upload", "movies/1/poster", tt.data).
+					Return(uploaded, tt.uploadError).
+					Once()
+			}
+			if tt.respError == "" || tt.setterError != nil {
+				posterSetterMock.
+					On("SetMoviePoster", 1, image).
+					Return(tt.previous, tt.setterError).
+					Once()
+			}
+			if tt.respError == "" {
+				// Replaced image is removed only after the new one is saved
+				imageUploaderMock.
+					On("Discard", tt.previous).
+					Return(nil).
+					Once()
+			}
+			if tt.setterError != nil {
+				imageUploaderMock.
+					On("Discard", image).
+					Return(nil).
+					Once()
+			}
+
+			handler := posterUpdate.New(slogdiscard.NewDiscardLogger(), posterSetterMock, imageUploaderMock, maxSize)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /{id}/poster", handler)
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			part, err := mw.CreateFormFile(tt.field, "poster.jpg")
+			require.NoError(t, err)
+			_, err = part.Write(tt.data)
+			require.NoError(t, err)
+			require.NoError(t, mw.Close())
+
+			req, err := http.NewRequest(http.MethodPut, "/1/poster", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp posterUpdate.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				// Prefix is not sent to clients
+				sent := *image
+				sent.Prefix = ""
+				require.Equal(t, &sent, resp.Image)
+			}
+		})
+	}
+}
@@ -12,9 +12,45 @@ type MovieGetter struct {
 	mock.Mock
 }
 
-// GetMovies provides a mock function with given fields: limit, offset, orderBy, asc
-func (_m *MovieGetter) GetMovies(limit int, offset int, orderBy string, asc bool) ([]entity.Movie, error) {
-	ret := _m.Called(limit, offset, orderBy, asc)
+// EmbedActors provides a mock function with given fields: movies, langs
+func (_m *MovieGetter) EmbedActors(movies []entity.Movie, langs []string) error {
+	ret := _m.Called(movies, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmbedActors")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]entity.Movie, []string) error); ok {
+		r0 = rf(movies, langs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlagWatchlisted provides a mock function with given fields: username, movies
+func (_m *MovieGetter) FlagWatchlisted(username string, movies []entity.Movie) error {
+	ret := _m.Called(username, movies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagWatchlisted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []entity.Movie) error); ok {
+		r0 = rf(username, movies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMovies provides a mock function with given fields: limit, offset, orderBy, asc, filter, langs
+func (_m *MovieGetter) GetMovies(limit int, offset int, orderBy string, asc bool, filter *entity.MovieFilter, langs []string) ([]entity.Movie, error) {
+	ret := _m.Called(limit, offset, orderBy, asc, filter, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMovies")
@@ -22,19 +58,19 @@ func (_m *MovieGetter) GetMovies(limit int, offset int, orderBy string, asc bool
 
 	var r0 []entity.Movie
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, string, bool) ([]entity.Movie, error)); ok {
-		return rf(limit, offset, orderBy, asc)
+	if rf, ok := ret.Get(0).(func(int, int, string, bool, *entity.MovieFilter, []string) ([]entity.Movie, error)); ok {
+		return rf(limit, offset, orderBy, asc, filter, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string, bool) []entity.Movie); ok {
-		r0 = rf(limit, offset, orderBy, asc)
+	if rf, ok := ret.Get(0).(func(int, int, string, bool, *entity.MovieFilter, []string) []entity.Movie); ok {
+		r0 = rf(limit, offset, orderBy, asc, filter, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Movie)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string, bool) error); ok {
-		r1 = rf(limit, offset, orderBy, asc)
+	if rf, ok := ret.Get(1).(func(int, int, string, bool, *entity.MovieFilter, []string) error); ok {
+		r1 = rf(limit, offset, orderBy, asc, filter, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,18 +1,31 @@
 package query
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/fieldset"
+	apiFilter "github.com/rmntim/movielab/internal/lib/api/filter"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieGetter
 type MovieGetter interface {
-	GetMovies(limit, offset int, orderBy string, asc bool) ([]entity.Movie, error)
+	GetMovies(limit, offset int, orderBy string, asc bool, filter *entity.MovieFilter, langs []string) ([]entity.Movie, error)
+	FlagWatchlisted(username string, movies []entity.Movie) error
+	EmbedActors(movies []entity.Movie, langs []string) error
 }
 
 type Response struct {
@@ -20,6 +33,12 @@ type Response struct {
 	Movies []entity.Movie `json:"movies"`
 }
 
+// SparseResponse has movies trimmed to fields requested with `fields`
+type SparseResponse struct {
+	resp.Response
+	Movies []map[string]json.RawMessage `json:"movies"`
+}
+
 func New(log *slog.Logger, movieGetter MovieGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movies.query.New"
@@ -64,20 +83,189 @@ func New(log *slog.Logger, movieGetter MovieGetter) http.HandlerFunc {
 			orderBy = querySort[1:]
 		}
 
-		movies, err := movieGetter.GetMovies(limit, offset, orderBy, asc)
+		filter, param, err := parseFilter(r.URL.Query())
+		if err != nil {
+			log.Error("Failed to parse "+param, sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse "+param))
+			return
+		}
+
+		if filter.IncludeDeleted && r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		include, err := fieldset.Include(r.URL.Query(), "actors")
+		if err != nil {
+			log.Error("Failed to parse include", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse include"))
+			return
+		}
+
+		fields, err := fieldset.Fields(r.URL.Query(), entity.Movie{})
+		if err != nil {
+			log.Error("Failed to parse fields", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse fields"))
+			return
+		}
+
+		if err := validate.Struct(filter); err != nil {
+			log.Error("Invalid filter", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		// Translated fields depend on requested language
+		w.Header().Add("Vary", "Accept-Language")
+
+		movies, err := movieGetter.GetMovies(limit, offset, orderBy, asc, filter, locale.FromRequest(r))
 		if err != nil {
 			log.Error("Failed to get movies", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get movies"))
+			status, errResp := resp.StorageError(err, "Failed to get movies")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 		if movies == nil {
 			movies = []entity.Movie{}
 		}
 
-		render.JSON(w, r, Response{
+		if err := movieGetter.FlagWatchlisted(r.Header.Get("x-username"), movies); err != nil {
+			log.Error("Failed to check watchlist", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get movies")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if include["actors"] {
+			if err := movieGetter.EmbedActors(movies, locale.FromRequest(r)); err != nil {
+				log.Error("Failed to get actors", sl.Err(err))
+				status, errResp := resp.StorageError(err, "Failed to get movies")
+				w.WriteHeader(status)
+				render.JSON(w, r, errResp)
+				return
+			}
+		}
+
+		var response any = Response{
 			Response: resp.Ok(),
 			Movies:   movies,
-		})
+		}
+		if fields != nil {
+			// Included actors are kept in sparse fieldset
+			if include["actors"] {
+				fields = append(fields, "actors")
+			}
+			selected, err := fieldset.Select(movies, fields)
+			if err != nil {
+				log.Error("Failed to select fields", sl.Err(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("Failed to get movies"))
+				return
+			}
+			response = SparseResponse{
+				Response: resp.Ok(),
+				Movies:   selected,
+			}
+		}
+
+		tag, err := etag.Digest(response)
+		if err != nil {
+			log.Error("Failed to tag movies", sl.Err(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("Failed to get movies"))
+			return
+		}
+		// List was last modified when the latest of its movies was
+		var modified time.Time
+		for _, movie := range movies {
+			if last := movie.LastModified(); last.After(modified) {
+				modified = last
+			}
+		}
+		if etag.NotModified(w, r, tag, modified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		render.JSON(w, r, response)
+	}
+}
+
+// parseFilter reads movie filter from query parameters, returning name of malformed parameter on error.
+// Country and currency codes are uppercased, language code is lowercased.
+func parseFilter(query url.Values) (*entity.MovieFilter, string, error) {
+	filter := entity.MovieFilter{
+		Country:  strings.ToUpper(query.Get("country")),
+		Language: strings.ToLower(query.Get("language")),
+		Currency: strings.ToUpper(query.Get("currency")),
+	}
+
+	country, certification, _ := strings.Cut(query.Get("certification"), ":")
+	filter.CertificationCountry = strings.ToUpper(country)
+	filter.Certification = certification
+
+	ints := []struct {
+		param string
+		dest  *int
+	}{
+		{"runtime_min", &filter.MinRuntime},
+		{"runtime_max", &filter.MaxRuntime},
+	}
+	for _, p := range ints {
+		if value := query.Get(p.param); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, p.param, err
+			}
+			*p.dest = parsed
+		}
+	}
+
+	amounts := []struct {
+		param string
+		dest  *int64
+	}{
+		{"budget_min", &filter.MinBudget},
+		{"budget_max", &filter.MaxBudget},
+		{"box_office_min", &filter.MinBoxOffice},
+		{"box_office_max", &filter.MaxBoxOffice},
+	}
+	for _, p := range amounts {
+		if value := query.Get(p.param); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, p.param, err
+			}
+			*p.dest = parsed
+		}
 	}
+
+	tags, ok := entity.NormalizeTags(query["tag"])
+	if !ok {
+		return nil, "tag", errors.New("invalid tag")
+	}
+	filter.Tags = tags
+
+	awards, param, err := apiFilter.Awards(query)
+	if err != nil {
+		return nil, param, err
+	}
+	filter.AwardFilter = awards
+
+	filter.IncludeDeleted, err = apiFilter.IncludeDeleted(query)
+	if err != nil {
+		return nil, "include_deleted", err
+	}
+
+	return &filter, "", nil
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/query"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/query/mocks"
@@ -13,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestMovieQuery(t *testing.T) {
@@ -21,10 +24,17 @@ func TestMovieQuery(t *testing.T) {
 		limit     string
 		offset    string
 		orderBy   string
+		filter    string
+		role      string
 		respBody  []entity.Movie
 		respCode  int
 		respError string
 		mockError error
+		flagError error
+		// mockFilter is expected filter, empty by default
+		mockFilter *entity.MovieFilter
+		// notModified sends tag of the returned list as If-None-Match
+		notModified bool
 	}{
 		{
 			name:     "Success asc",
@@ -34,6 +44,14 @@ func TestMovieQuery(t *testing.T) {
 			respBody: []entity.Movie{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:        "Not modified",
+			limit:       "10",
+			offset:      "0",
+			respBody:    []entity.Movie{},
+			respCode:    http.StatusNotModified,
+			notModified: true,
+		},
 		{
 			name:     "Success desc",
 			limit:    "10",
@@ -42,6 +60,100 @@ func TestMovieQuery(t *testing.T) {
 			respBody: []entity.Movie{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "Success with filters",
+			limit:    "10",
+			offset:   "0",
+			filter:   "runtime_min=90&country=us&language=EN&certification=us:PG-13&currency=usd&budget_max=1000000",
+			respBody: []entity.Movie{},
+			respCode: http.StatusOK,
+			mockFilter: &entity.MovieFilter{
+				MinRuntime:           90,
+				Country:              "US",
+				Language:             "en",
+				CertificationCountry: "US",
+				Certification:        "PG-13",
+				Currency:             "USD",
+				MaxBudget:            1000000,
+			},
+		},
+		{
+			name:       "Success with tags",
+			limit:      "10",
+			offset:     "0",
+			filter:     "tag=Cult+Classic&tag=cult%20classic&tag=noir",
+			respBody:   []entity.Movie{},
+			respCode:   http.StatusOK,
+			mockFilter: &entity.MovieFilter{Tags: []string{"cult classic", "noir"}},
+		},
+		{
+			name:       "Success with awards",
+			limit:      "10",
+			offset:     "0",
+			filter:     "award_category=1&award_won=true&min_wins=2",
+			respBody:   []entity.Movie{},
+			respCode:   http.StatusOK,
+			mockFilter: &entity.MovieFilter{AwardFilter: entity.AwardFilter{AwardCategoryID: 1, AwardWon: true, MinWins: 2}},
+		},
+		{
+			name:       "Success including deleted",
+			limit:      "10",
+			offset:     "0",
+			filter:     "include_deleted=true",
+			role:       "admin",
+			respBody:   []entity.Movie{},
+			respCode:   http.StatusOK,
+			mockFilter: &entity.MovieFilter{IncludeDeleted: true},
+		},
+		{
+			name:      "Include deleted unauthorized",
+			filter:    "include_deleted=true",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad include deleted",
+			filter:    "include_deleted=maybe",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse include_deleted",
+		},
+		{
+			name:      "Bad award filter",
+			filter:    "award_won=maybe",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse award_won",
+		},
+		{
+			name:      "Bad tag",
+			filter:    "tag=",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse tag",
+		},
+		{
+			name:      "Bad runtime",
+			filter:    "runtime_min=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse runtime_min",
+		},
+		{
+			name:      "Bad budget",
+			filter:    "budget_max=1.5",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse budget_max",
+		},
+		{
+			name:      "Invalid country",
+			filter:    "country=XX",
+			respCode:  http.StatusBadRequest,
+			respError: "field Country is invalid",
+		},
+		{
+			name:      "Certification without country",
+			filter:    "certification=:PG",
+			respCode:  http.StatusBadRequest,
+			respError: "field CertificationCountry is invalid",
+		},
 		{
 			name:      "Bad limit",
 			limit:     "a",
@@ -62,6 +174,14 @@ func TestMovieQuery(t *testing.T) {
 			respError: "Failed to get movies",
 			mockError: errors.New("unexpected error"),
 		},
+		{
+			name:      "FlagWatchlisted error",
+			limit:     "10",
+			offset:    "0",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get movies",
+			flagError: errors.New("failed to check watchlist"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,23 +191,46 @@ func TestMovieQuery(t *testing.T) {
 
 			movieGetterMock := mocks.NewMovieGetter(t)
 
-			if tt.respError == "" || tt.mockError != nil {
+			mockFilter := tt.mockFilter
+			if mockFilter == nil {
+				mockFilter = &entity.MovieFilter{}
+			}
+
+			if tt.respError == "" || tt.mockError != nil || tt.flagError != nil {
 				movieGetterMock.
-					On("GetMovies", mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).
+					On("GetMovies", mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("bool"), mockFilter, []string{"fr-ca", "fr"}).
 					Return(tt.respBody, tt.mockError)
 			}
+			if tt.respError == "" || tt.flagError != nil {
+				movieGetterMock.
+					On("FlagWatchlisted", "user", mock.AnythingOfType("[]entity.Movie")).
+					Return(tt.flagError).
+					Once()
+			}
 
 			handler := query.New(slogdiscard.NewDiscardLogger(), movieGetterMock)
 
 			req, err := http.NewRequest(http.MethodGet,
-				fmt.Sprintf("/?limit=%s&offset=%s&sort=%s", tt.limit, tt.offset, tt.orderBy),
+				fmt.Sprintf("/?limit=%s&offset=%s&sort=%s&%s", tt.limit, tt.offset, tt.orderBy, tt.filter),
 				nil)
 			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "fr-CA, en;q=0")
+			req.Header.Set("x-username", "user")
+			req.Header.Set("x-role", tt.role)
+			if tt.notModified {
+				tag, err := etag.Digest(query.Response{Response: resp.Ok(), Movies: tt.respBody})
+				require.NoError(t, err)
+				req.Header.Set("If-None-Match", tag)
+			}
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
 			require.Equal(t, tt.respCode, rr.Code)
+			if tt.notModified {
+				require.Empty(t, rr.Body.Bytes())
+				return
+			}
 			var resp query.Response
 			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
 
@@ -96,3 +239,174 @@ func TestMovieQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestMovieQueryInclude(t *testing.T) {
+	movies := []entity.Movie{{ID: 1, NewMovie: entity.NewMovie{Title: "Heat", Rating: 8, ActorIDs: []int32{2}}}}
+	actors := []entity.Actor{{ID: 2, NewActor: entity.NewActor{Name: "Al Pacino", Sex: "male"}, MovieIDs: []int32{1}}}
+	actorsJSON := `[{"id":2,"name":"Al Pacino","sex":"male","birthdate":"0001-01-01T00:00:00Z","age":0,"movie_ids":[1],"tags":null}]`
+
+	tests := []struct {
+		name       string
+		query      string
+		respCode   int
+		respError  string
+		embedError error
+		// noActors makes embedding find no actors of the movie
+		noActors bool
+		// respBody is expected JSON of response when set
+		respBody string
+	}{
+		{
+			name:     "Include actors",
+			query:    "include=actors",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","movies":[{"id":1,"title":"Heat","release_date":"0001-01-01T00:00:00Z","rating":8,"actor_ids":[2],
+				"actors":` + actorsJSON + `,"tags":null}]}`,
+		},
+		{
+			name:     "Sparse fields",
+			query:    "fields=title,rating",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","movies":[{"id":1,"title":"Heat","rating":8}]}`,
+		},
+		{
+			name:     "Sparse fields with actors",
+			query:    "fields=title&include=actors",
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","movies":[{"id":1,"title":"Heat","actors":` + actorsJSON + `}]}`,
+		},
+		{
+			name:     "Include actors of movie without actors",
+			query:    "include=actors",
+			noActors: true,
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","movies":[{"id":1,"title":"Heat","release_date":"0001-01-01T00:00:00Z","rating":8,"actor_ids":[2],
+				"actors":[],"tags":null}]}`,
+		},
+		{
+			name:     "Sparse fields with no actors",
+			query:    "fields=title&include=actors",
+			noActors: true,
+			respCode: http.StatusOK,
+			respBody: `{"status":"Ok","movies":[{"id":1,"title":"Heat","actors":[]}]}`,
+		},
+		{
+			name:      "Unknown include",
+			query:     "include=reviews",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse include",
+		},
+		{
+			name:      "Unknown field",
+			query:     "fields=title,votes",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse fields",
+		},
+		{
+			name:       "EmbedActors error",
+			query:      "include=actors",
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get movies",
+			embedError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieGetterMock := mocks.NewMovieGetter(t)
+			movieGetterMock.
+				On("GetMovies", 10, 0, "title", false, &entity.MovieFilter{}, []string{"en"}).
+				Return(append([]entity.Movie(nil), movies...), nil).
+				Maybe()
+			movieGetterMock.
+				On("FlagWatchlisted", "", mock.AnythingOfType("[]entity.Movie")).
+				Return(nil).
+				Maybe()
+			movieGetterMock.
+				On("EmbedActors", mock.AnythingOfType("[]entity.Movie"), []string{"en"}).
+				Run(func(args mock.Arguments) {
+					embedded := actors
+					if tt.noActors {
+						embedded = []entity.Actor{}
+					}
+					args.Get(0).([]entity.Movie)[0].Actors = &embedded
+				}).
+				Return(tt.embedError).
+				Maybe()
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), movieGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			if tt.respBody != "" {
+				require.JSONEq(t, tt.respBody, rr.Body.String())
+				return
+			}
+			var resp resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
+
+func TestMovieQueryLastModified(t *testing.T) {
+	movies := []entity.Movie{
+		{ID: 1, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, UpdatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		respCode        int
+	}{
+		{
+			name:            "Not modified since",
+			ifModifiedSince: "Wed, 03 Jan 2024 00:00:00 GMT",
+			respCode:        http.StatusNotModified,
+		},
+		{
+			name:            "Modified since",
+			ifModifiedSince: "Tue, 02 Jan 2024 00:00:00 GMT",
+			respCode:        http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieGetterMock := mocks.NewMovieGetter(t)
+			movieGetterMock.
+				On("GetMovies", mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("string"), mock.AnythingOfType("bool"), &entity.MovieFilter{}, []string{"en"}).
+				Return(movies, nil)
+			movieGetterMock.
+				On("FlagWatchlisted", "", mock.AnythingOfType("[]entity.Movie")).
+				Return(nil)
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), movieGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+			req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			// List was last modified with its latest movie
+			require.Equal(t, "Wed, 03 Jan 2024 00:00:00 GMT", rr.Header().Get("Last-Modified"))
+		})
+	}
+}
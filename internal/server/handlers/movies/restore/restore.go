@@ -0,0 +1,69 @@
+package restore
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieRestorer
+type MovieRestorer interface {
+	RestoreMovie(id int, audit entity.AuditInfo) error
+	GetMovieById(id int, langs []string) (*entity.Movie, error)
+}
+
+type Response struct {
+	resp.Response
+	Movie *entity.Movie `json:"movie"`
+}
+
+// New restores deleted movie together with its cast and other links
+func New(log *slog.Logger, movieRestorer MovieRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.restore.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		if err := movieRestorer.RestoreMovie(id, audit.FromRequest(r)); err != nil {
+			log.Error("Failed to restore movie", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to restore movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		movie, err := movieRestorer.GetMovieById(id, nil)
+		if err != nil {
+			log.Error("Failed to get movie", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Movie:    movie,
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/revisions/query"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/revisions/query/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMovieRevisionsQuery(t *testing.T) {
+	revisions := []entity.MovieRevision{
+		{
+			Revision:  2,
+			Username:  "admin",
+			CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Movie:     entity.Movie{ID: 1, NewMovie: entity.NewMovie{Title: "Heat", ActorIDs: []int32{1}}},
+		},
+		{
+			Revision:  1,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Movie:     entity.Movie{ID: 1, NewMovie: entity.NewMovie{Title: "Heat (1995)", ActorIDs: []int32{}}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		role       string
+		mockLimit  int
+		mockOffset int
+		respBody   []entity.MovieRevision
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:      "Success",
+			id:        "1",
+			mockLimit: 10,
+			respBody:  revisions,
+			respCode:  http.StatusOK,
+		},
+		{
+			name:       "Success with paging",
+			id:         "1",
+			query:      "limit=5&offset=5",
+			mockLimit:  5,
+			mockOffset: 5,
+			respBody:   []entity.MovieRevision{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad limit",
+			id:        "1",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			mockLimit: 10,
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "GetMovieRevisions error",
+			id:        "1",
+			mockLimit: 10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get revisions",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getterMock := mocks.NewMovieRevisionsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				getterMock.
+					On("GetMovieRevisions", 1, tt.mockLimit, tt.mockOffset).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), getterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /{id}/revisions", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/revisions?%s", tt.id, tt.query), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Revisions)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
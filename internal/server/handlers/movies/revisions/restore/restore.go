@@ -0,0 +1,77 @@
+package restore
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieRevisionRestorer
+type MovieRevisionRestorer interface {
+	RestoreMovieRevision(id, revision int, audit entity.AuditInfo) error
+	GetMovieById(id int, langs []string) (*entity.Movie, error)
+}
+
+type Response struct {
+	resp.Response
+	Movie *entity.Movie `json:"movie"`
+}
+
+// New reverts movie to its past revision, the result is saved as a new revision
+func New(log *slog.Logger, movieRevisionRestorer MovieRevisionRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.revisions.restore.New"
+
+		log := log.With(slog.String("op", op))
+
+		if r.Header.Get("x-role") != "admin" {
+			log.Error("Insufficient permissions", slog.String("role", r.Header.Get("x-role")))
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		revision, err := strconv.Atoi(r.PathValue("rev"))
+		if err != nil {
+			log.Error("Failed to parse revision", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse revision"))
+			return
+		}
+
+		if err := movieRevisionRestorer.RestoreMovieRevision(id, revision, audit.FromRequest(r)); err != nil {
+			log.Error("Failed to restore revision", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to restore revision")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		movie, err := movieRevisionRestorer.GetMovieById(id, nil)
+		if err != nil {
+			log.Error("Failed to get movie", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Movie:    movie,
+		})
+	}
+}
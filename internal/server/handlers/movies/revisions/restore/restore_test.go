@@ -0,0 +1,137 @@
+package restore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/revisions/restore"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/revisions/restore/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovieRevisionRestore(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		revision  string
+		role      string
+		respBody  *entity.Movie
+		respCode  int
+		respError string
+		mockError error
+		getError  error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			revision: "2",
+			respBody: &entity.Movie{ID: 1, NewMovie: entity.NewMovie{Title: "Heat", ActorIDs: []int32{1, 2}}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			revision:  "2",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			revision:  "2",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad revision",
+			id:        "1",
+			revision:  "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse revision",
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			revision:  "2",
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "Revision not found",
+			id:        "1",
+			revision:  "2",
+			respCode:  http.StatusNotFound,
+			respError: "Revision not found",
+			mockError: storage.ErrRevisionNotFound,
+		},
+		{
+			name:      "RestoreMovieRevision error",
+			id:        "1",
+			revision:  "2",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to restore revision",
+			mockError: errors.New("unexpected error"),
+		},
+		{
+			name:      "GetMovieById error",
+			id:        "1",
+			revision:  "2",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get movie",
+			getError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			restorerMock := mocks.NewMovieRevisionRestorer(t)
+
+			if tt.respError == "" || tt.mockError != nil || tt.getError != nil {
+				restorerMock.
+					On("RestoreMovieRevision", 1, 2, entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
+					Return(tt.mockError).
+					Once()
+			}
+			if tt.respError == "" || tt.getError != nil {
+				restorerMock.On("GetMovieById", 1, []string(nil)).Return(tt.respBody, tt.getError).Once()
+			}
+
+			handler := restore.New(slogdiscard.NewDiscardLogger(), restorerMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/revisions/{rev}/restore", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/revisions/%s/restore", tt.id, tt.revision), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp restore.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Movie)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
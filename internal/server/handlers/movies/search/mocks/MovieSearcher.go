@@ -12,9 +12,27 @@ type MovieSearcher struct {
 	mock.Mock
 }
 
-// SearchMovies provides a mock function with given fields: title, actorName, limit, offset
-func (_m *MovieSearcher) SearchMovies(title string, actorName string, limit int, offset int) ([]entity.Movie, error) {
-	ret := _m.Called(title, actorName, limit, offset)
+// FlagWatchlisted provides a mock function with given fields: username, movies
+func (_m *MovieSearcher) FlagWatchlisted(username string, movies []entity.Movie) error {
+	ret := _m.Called(username, movies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagWatchlisted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []entity.Movie) error); ok {
+		r0 = rf(username, movies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchMovies provides a mock function with given fields: title, actorName, director, limit, offset, langs
+func (_m *MovieSearcher) SearchMovies(title string, actorName string, director string, limit int, offset int, langs []string) ([]entity.Movie, error) {
+	ret := _m.Called(title, actorName, director, limit, offset, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SearchMovies")
@@ -22,19 +40,19 @@ func (_m *MovieSearcher) SearchMovies(title string, actorName string, limit int,
 
 	var r0 []entity.Movie
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]entity.Movie, error)); ok {
-		return rf(title, actorName, limit, offset)
+	if rf, ok := ret.Get(0).(func(string, string, string, int, int, []string) ([]entity.Movie, error)); ok {
+		return rf(title, actorName, director, limit, offset, langs)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []entity.Movie); ok {
-		r0 = rf(title, actorName, limit, offset)
+	if rf, ok := ret.Get(0).(func(string, string, string, int, int, []string) []entity.Movie); ok {
+		r0 = rf(title, actorName, director, limit, offset, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Movie)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
-		r1 = rf(title, actorName, limit, offset)
+	if rf, ok := ret.Get(1).(func(string, string, string, int, int, []string) error); ok {
+		r1 = rf(title, actorName, director, limit, offset, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
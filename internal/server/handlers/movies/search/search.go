@@ -3,6 +3,7 @@ package search
 import (
 	"github.com/go-chi/render"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/locale"
 	"github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"log/slog"
@@ -12,7 +13,8 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieSearcher
 type MovieSearcher interface {
-	SearchMovies(title, actorName string, limit, offset int) ([]entity.Movie, error)
+	SearchMovies(title, actorName, director string, limit, offset int, langs []string) ([]entity.Movie, error)
+	FlagWatchlisted(username string, movies []entity.Movie) error
 }
 
 type Response struct {
@@ -55,12 +57,17 @@ func New(log *slog.Logger, movieSearcher MovieSearcher) http.HandlerFunc {
 
 		title := r.URL.Query().Get("title")
 		actorName := r.URL.Query().Get("actor")
+		director := r.URL.Query().Get("director")
 
-		movies, err := movieSearcher.SearchMovies(title, actorName, limit, offset)
+		// Translated fields depend on requested language
+		w.Header().Add("Vary", "Accept-Language")
+
+		movies, err := movieSearcher.SearchMovies(title, actorName, director, limit, offset, locale.FromRequest(r))
 		if err != nil {
 			log.Error("Failed to search movies", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, response.Error("Failed to search movies"))
+			status, errResp := response.StorageError(err, "Failed to search movies")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
@@ -68,6 +75,14 @@ func New(log *slog.Logger, movieSearcher MovieSearcher) http.HandlerFunc {
 			movies = []entity.Movie{}
 		}
 
+		if err := movieSearcher.FlagWatchlisted(r.Header.Get("x-username"), movies); err != nil {
+			log.Error("Failed to check watchlist", sl.Err(err))
+			status, errResp := response.StorageError(err, "Failed to search movies")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
 		render.JSON(w, r, Response{
 			Response: response.Ok(),
 			Movies:   movies,
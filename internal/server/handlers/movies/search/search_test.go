@@ -20,12 +20,14 @@ func TestMovieSearch(t *testing.T) {
 		name      string
 		title     string
 		actor     string
+		director  string
 		limit     string
 		offset    string
 		respBody  []entity.Movie
 		respCode  int
 		respError string
 		mockError error
+		flagError error
 	}{
 		{
 			name:     "Success",
@@ -36,6 +38,14 @@ func TestMovieSearch(t *testing.T) {
 			respBody: []entity.Movie{},
 			respCode: http.StatusOK,
 		},
+		{
+			name:     "Success by director",
+			director: "Test",
+			limit:    "10",
+			offset:   "0",
+			respBody: []entity.Movie{},
+			respCode: http.StatusOK,
+		},
 		{
 			name:      "Bad limit",
 			limit:     "a",
@@ -58,6 +68,14 @@ func TestMovieSearch(t *testing.T) {
 			respError: "Failed to search movies",
 			mockError: errors.New("unexpected error"),
 		},
+		{
+			name:      "FlagWatchlisted error",
+			limit:     "10",
+			offset:    "0",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to search movies",
+			flagError: errors.New("failed to check watchlist"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,19 +85,26 @@ func TestMovieSearch(t *testing.T) {
 
 			movieSearcherMock := mocks.NewMovieSearcher(t)
 
-			if tt.respError == "" || tt.mockError != nil {
+			if tt.respError == "" || tt.mockError != nil || tt.flagError != nil {
 				movieSearcherMock.
-					On("SearchMovies", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).
+					On("SearchMovies", mock.AnythingOfType("string"), mock.AnythingOfType("string"), tt.director, mock.AnythingOfType("int"), mock.AnythingOfType("int"), []string{"de"}).
 					Return(nil, tt.mockError).
 					Once()
 			}
+			if tt.respError == "" || tt.flagError != nil {
+				movieSearcherMock.
+					On("FlagWatchlisted", "user", mock.AnythingOfType("[]entity.Movie")).
+					Return(tt.flagError).
+					Once()
+			}
 
 			handler := search.New(slogdiscard.NewDiscardLogger(), movieSearcherMock)
 
 			req, err := http.NewRequest(http.MethodGet,
-				fmt.Sprintf("/?title=%s&actor=%s&limit=%s&offset=%s", tt.title, tt.actor, tt.limit, tt.offset),
+				fmt.Sprintf("/?title=%s&actor=%s&director=%s&limit=%s&offset=%s&lang=de", tt.title, tt.actor, tt.director, tt.limit, tt.offset),
 				nil)
 			require.NoError(t, err)
+			req.Header.Set("x-username", "user")
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
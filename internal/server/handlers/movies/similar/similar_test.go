@@ -0,0 +1,123 @@
+package similar_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/similar"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/similar/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSimilarMovies(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		query     string
+		limit     int
+		offset    int
+		mockBody  []entity.Recommendation
+		respBody  []entity.Recommendation
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:  "Success",
+			id:    "1",
+			limit: 10,
+			mockBody: []entity.Recommendation{
+				{Movie: entity.Movie{ID: 2}, Score: 0.5, Reasons: []entity.RecommendationReason{{Kind: entity.ReasonReleaseEra, Score: 0.5, Description: "Released in the same year"}}},
+			},
+			respBody: []entity.Recommendation{
+				{Movie: entity.Movie{ID: 2}, Score: 0.5, Reasons: []entity.RecommendationReason{{Kind: entity.ReasonReleaseEra, Score: 0.5, Description: "Released in the same year"}}},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No similar movies",
+			id:       "1",
+			query:    "limit=5&offset=5",
+			limit:    5,
+			offset:   5,
+			respBody: []entity.Recommendation{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Bad limit",
+			id:        "1",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			id:        "1",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			limit:     10,
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "GetSimilarMovies error",
+			id:        "1",
+			limit:     10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get similar movies",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			similarMoviesGetterMock := mocks.NewSimilarMoviesGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				similarMoviesGetterMock.
+					On("GetSimilarMovies", 1, tt.limit, tt.offset).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := similar.New(slogdiscard.NewDiscardLogger(), similarMoviesGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}/similar", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/similar?%s", tt.id, tt.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp similar.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Movies)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package create_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/tags/create"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/tags/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMovieTagCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		body      string
+		tag       string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			body:     `{"name": " Cult  Classic "}`,
+			tag:      "cult classic",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			body:      `{"name": "noir"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Bad body",
+			id:        "1",
+			body:      `{`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid request",
+		},
+		{
+			name:      "Missing name",
+			id:        "1",
+			body:      `{}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Name is required",
+		},
+		{
+			name:      "Invalid tag",
+			id:        "1",
+			body:      fmt.Sprintf(`{"name": "%s"}`, strings.Repeat("a", 51)),
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid tag",
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			body:      `{"name": "noir"}`,
+			tag:       "noir",
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "Tag name taken concurrently",
+			id:        "1",
+			body:      `{"name": "noir"}`,
+			tag:       "noir",
+			respCode:  http.StatusConflict,
+			respError: "Duplicate record: noir",
+			mockError: fmt.Errorf("storage.postgres.AddMovieTag: %w",
+				&storage.ConstraintError{Err: storage.ErrDuplicate, Constraint: "tags_name_key", Value: "noir"}),
+		},
+		{
+			name:      "AddMovieTag error",
+			id:        "1",
+			body:      `{"name": "noir"}`,
+			tag:       "noir",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to add tag",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieTagAdderMock := mocks.NewMovieTagAdder(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				movieTagAdderMock.
+					On("AddMovieTag", 1, tt.tag).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), movieTagAdderMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/tags", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/tags", tt.id), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("x-role", "user")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, tt.tag, resp.Tag)
+			}
+		})
+	}
+}
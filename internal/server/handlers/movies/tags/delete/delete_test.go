@@ -0,0 +1,112 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/tags/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/tags/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovieTagDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		tag       string
+		role      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			tag:      "Cult%20Classic",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			tag:       "noir",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			tag:       "noir",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Invalid tag",
+			id:        "1",
+			tag:       "%20",
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid tag",
+		},
+		{
+			name:      "Tag not found",
+			id:        "1",
+			tag:       "Cult%20Classic",
+			respCode:  http.StatusNotFound,
+			respError: "Tag not found",
+			mockError: storage.ErrTagNotFound,
+		},
+		{
+			name:      "RemoveMovieTag error",
+			id:        "1",
+			tag:       "Cult%20Classic",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to remove tag",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieTagRemoverMock := mocks.NewMovieTagRemover(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				movieTagRemoverMock.
+					On("RemoveMovieTag", 1, "cult classic").
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := delete.New(slogdiscard.NewDiscardLogger(), movieTagRemoverMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/tags/{tag}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s/tags/%s", tt.id, tt.tag), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	movieTranslationsDelete "github.com/rmntim/movielab/internal/server/handlers/movies/translations/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/translations/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovieTranslationDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      string
+		lang      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			role:     "admin",
+			lang:     "FR",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Not admin",
+			role:      "user",
+			lang:      "fr",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Invalid language",
+			role:      "admin",
+			lang:      "f",
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid language",
+		},
+		{
+			name:      "Translation not found",
+			role:      "admin",
+			lang:      "fr",
+			respCode:  http.StatusNotFound,
+			respError: "Translation not found",
+			mockError: storage.ErrTranslationNotFound,
+		},
+		{
+			name:      "RemoveMovieTranslation error",
+			role:      "admin",
+			lang:      "fr",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to remove translation",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieTranslationRemoverMock := mocks.NewMovieTranslationRemover(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				movieTranslationRemoverMock.
+					On("RemoveMovieTranslation", 1, "fr").
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := movieTranslationsDelete.New(slogdiscard.NewDiscardLogger(), movieTranslationRemoverMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/translations/{lang}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/1/translations/%s", tt.lang), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieTranslationsGetter
+type MovieTranslationsGetter interface {
+	GetMovieTranslations(movieID int) ([]entity.MovieTranslation, error)
+}
+
+type Response struct {
+	resp.Response
+	Translations []entity.MovieTranslation `json:"translations"`
+}
+
+func New(log *slog.Logger, movieTranslationsGetter MovieTranslationsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.movies.translations.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		translations, err := movieTranslationsGetter.GetMovieTranslations(movieID)
+		if err != nil {
+			log.Error("Failed to get translations", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get translations")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if translations == nil {
+			translations = []entity.MovieTranslation{}
+		}
+
+		render.JSON(w, r, Response{
+			Response:     resp.Ok(),
+			Translations: translations,
+		})
+	}
+}
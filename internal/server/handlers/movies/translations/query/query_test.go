@@ -0,0 +1,91 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	movieTranslationsQuery "github.com/rmntim/movielab/internal/server/handlers/movies/translations/query"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/translations/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovieTranslationsQuery(t *testing.T) {
+	translations := []entity.MovieTranslation{
+		{Lang: "fr", NewMovieTranslation: entity.NewMovieTranslation{Title: "Titre"}},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		mockBody  []entity.MovieTranslation
+		respBody  []entity.MovieTranslation
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			mockBody: translations,
+			respBody: translations,
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "No translations",
+			id:       "1",
+			respBody: []entity.MovieTranslation{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "GetMovieTranslations error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get translations",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieTranslationsGetterMock := mocks.NewMovieTranslationsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				movieTranslationsGetterMock.
+					On("GetMovieTranslations", 1).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := movieTranslationsQuery.New(slogdiscard.NewDiscardLogger(), movieTranslationsGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /{id}/translations", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/translations", tt.id), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp movieTranslationsQuery.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+			require.Equal(t, tt.respBody, resp.Translations)
+		})
+	}
+}
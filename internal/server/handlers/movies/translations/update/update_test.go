@@ -0,0 +1,127 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	movieTranslationsUpdate "github.com/rmntim/movielab/internal/server/handlers/movies/translations/update"
+	"github.com/rmntim/movielab/internal/server/handlers/movies/translations/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMovieTranslationUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      string
+		lang      string
+		body      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			role:     "admin",
+			lang:     "pt-BR",
+			body:     `{"title": "Título", "description": "Descrição"}`,
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Not admin",
+			role:      "user",
+			lang:      "pt-BR",
+			body:      `{"title": "Título"}`,
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Invalid language",
+			role:      "admin",
+			lang:      "portuguese!",
+			body:      `{"title": "Título"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid language",
+		},
+		{
+			name:      "Invalid body",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{`,
+			respCode:  http.StatusBadRequest,
+			respError: "Invalid request",
+		},
+		{
+			name:      "Missing title",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{"description": "Descrição"}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Title is required",
+		},
+		{
+			name:      "Movie not found",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{"title": "Título"}`,
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "SetMovieTranslation error",
+			role:      "admin",
+			lang:      "pt-BR",
+			body:      `{"title": "Título"}`,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to set translation",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieTranslationSetterMock := mocks.NewMovieTranslationSetter(t)
+
+			var translation entity.MovieTranslation
+			_ = json.Unmarshal([]byte(tt.body), &translation.NewMovieTranslation)
+			translation.Lang = "pt-br"
+
+			if tt.respError == "" || tt.mockError != nil {
+				movieTranslationSetterMock.
+					On("SetMovieTranslation", 1, &translation).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handler := movieTranslationsUpdate.New(slogdiscard.NewDiscardLogger(), movieTranslationSetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /{id}/translations/{lang}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/1/translations/%s", tt.lang), bytes.NewReader([]byte(tt.body)))
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp movieTranslationsUpdate.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, &translation, resp.Translation)
+			}
+		})
+	}
+}
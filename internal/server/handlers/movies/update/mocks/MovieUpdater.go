@@ -12,9 +12,39 @@ type MovieUpdater struct {
 	mock.Mock
 }
 
-// GetMovieById provides a mock function with given fields: id
-func (_m *MovieUpdater) GetMovieById(id int) (*entity.Movie, error) {
-	ret := _m.Called(id)
+// GetMissingActorIDs provides a mock function with given fields: ids
+func (_m *MovieUpdater) GetMissingActorIDs(ids []int32) ([]int32, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissingActorIDs")
+	}
+
+	var r0 []int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int32) ([]int32, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int32) []int32); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int32) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieById provides a mock function with given fields: id, langs
+func (_m *MovieUpdater) GetMovieById(id int, langs []string) (*entity.Movie, error) {
+	ret := _m.Called(id, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMovieById")
@@ -22,19 +52,19 @@ func (_m *MovieUpdater) GetMovieById(id int) (*entity.Movie, error) {
 
 	var r0 *entity.Movie
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*entity.Movie, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) (*entity.Movie, error)); ok {
+		return rf(id, langs)
 	}
-	if rf, ok := ret.Get(0).(func(int) *entity.Movie); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, []string) *entity.Movie); ok {
+		r0 = rf(id, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Movie)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(int, []string) error); ok {
+		r1 = rf(id, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,17 +72,17 @@ func (_m *MovieUpdater) GetMovieById(id int) (*entity.Movie, error) {
 	return r0, r1
 }
 
-// UpdateMovie provides a mock function with given fields: id, movie
-func (_m *MovieUpdater) UpdateMovie(id int, movie *entity.Movie) error {
-	ret := _m.Called(id, movie)
+// UpdateMovie provides a mock function with given fields: id, movie, audit
+func (_m *MovieUpdater) UpdateMovie(id int, movie *entity.Movie, audit entity.AuditInfo) error {
+	ret := _m.Called(id, movie, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, *entity.Movie) error); ok {
-		r0 = rf(id, movie)
+	if rf, ok := ret.Get(0).(func(int, *entity.Movie, entity.AuditInfo) error); ok {
+		r0 = rf(id, movie, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
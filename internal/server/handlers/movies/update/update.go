@@ -1,21 +1,32 @@
 package update
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/audit"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/patch"
 	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
 	"github.com/rmntim/movielab/internal/lib/logger/sl"
 	"github.com/rmntim/movielab/internal/storage"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"slices"
 	"strconv"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieUpdater
 type MovieUpdater interface {
-	GetMovieById(id int) (*entity.Movie, error)
-	UpdateMovie(id int, movie *entity.Movie) error
+	GetMissingActorIDs(ids []int32) ([]int32, error)
+	GetMovieById(id int, langs []string) (*entity.Movie, error)
+	UpdateMovie(id int, movie *entity.Movie, audit entity.AuditInfo) error
 }
 
 type Response struct {
@@ -23,7 +34,9 @@ type Response struct {
 	resp.Response
 }
 
-func New(log *slog.Logger, movieUpdater MovieUpdater) http.HandlerFunc {
+// New replaces fields of movie with JSON body on PUT, or applies JSON Merge Patch or JSON Patch body to them on PATCH.
+// If-Match header is checked against ETag of the movie when present.
+func New(log *slog.Logger, movieUpdater MovieUpdater, requireIfMatch bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movies.update.New"
 
@@ -44,37 +57,152 @@ func New(log *slog.Logger, movieUpdater MovieUpdater) http.HandlerFunc {
 			return
 		}
 
-		oldMovie, err := movieUpdater.GetMovieById(id)
-		if err != nil {
-			if errors.Is(err, storage.ErrMovieNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("Movie not found"))
+		// PUT takes all fields of the movie, PATCH takes one of patch documents
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if r.Method == http.MethodPatch {
+			if mediaType != patch.MergePatch && mediaType != patch.JSONPatch {
+				log.Error("Unsupported content type", slog.String("content_type", mediaType))
+				w.Header().Set("Accept-Patch", patch.Accepted)
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				render.JSON(w, r, resp.Error("Unsupported content type"))
 				return
 			}
+		} else if mediaType != "application/json" {
+			log.Error("Unsupported content type", slog.String("content_type", mediaType))
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			render.JSON(w, r, resp.Error("Unsupported content type"))
+			return
+		}
+
+		ifMatch := r.Header.Get("If-Match")
+		if ifMatch == "" && requireIfMatch {
+			log.Error("Missing If-Match header")
+			w.WriteHeader(http.StatusPreconditionRequired)
+			render.JSON(w, r, resp.Error("If-Match header is required"))
+			return
+		}
+
+		// Updates are applied to original, untranslated fields
+		oldMovie, err := movieUpdater.GetMovieById(id, nil)
+		if err != nil {
 			log.Error("Failed to get movie", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to get movie"))
+			status, errResp := resp.StorageError(err, "Failed to get movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if ifMatch != "" && !etag.Match(ifMatch, oldMovie.Version) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Movie was modified"))
 			return
 		}
 
+		// Version of the read movie is expected by update, so concurrent changes are not overwritten
 		newMovie := *oldMovie
-		if err := render.DecodeJSON(r.Body, &newMovie); err != nil {
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("Failed to read body", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse body"))
+			return
+		}
+
+		if r.Method == http.MethodPatch {
+			original := oldMovie.NewMovie
+			// Patches may append to actor ids, so they are never null
+			if original.ActorIDs == nil {
+				original.ActorIDs = []int32{}
+			}
+			document, err := json.Marshal(original)
+			if err != nil {
+				log.Error("Failed to encode movie", sl.Err(err))
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("Failed to update movie"))
+				return
+			}
+
+			body, err = patch.Apply(mediaType, document, body)
+			if err != nil {
+				log.Error("Failed to apply patch", sl.Err(err))
+				if errors.Is(err, patch.ErrConflict) {
+					w.WriteHeader(http.StatusConflict)
+					render.JSON(w, r, resp.Error("Patch does not apply"))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Invalid patch"))
+				return
+			}
+		}
+
+		// Fields missing in body, or removed by patch, are cleared
+		newMovie.NewMovie = entity.NewMovie{}
+		if err := json.Unmarshal(body, &newMovie.NewMovie); err != nil {
 			log.Error("Failed to parse body", sl.Err(err))
 			w.WriteHeader(http.StatusBadRequest)
 			render.JSON(w, r, resp.Error("Failed to parse body"))
 			return
 		}
 
-		if err := movieUpdater.UpdateMovie(id, &newMovie); err != nil {
+		if err := validate.Struct(newMovie.NewMovie); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		missing, err := movieUpdater.GetMissingActorIDs(newMovie.NewMovie.ActorIDs)
+		if err != nil {
+			log.Error("Failed to check actors", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to update movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if len(missing) > 0 {
+			log.Error("Actors not found", slog.Any("ids", missing))
+			var fields []resp.FieldError
+			for i, actorID := range newMovie.NewMovie.ActorIDs {
+				if slices.Contains(missing, actorID) {
+					fields = append(fields, resp.FieldError{
+						Field:   fmt.Sprintf("actor_ids[%d]", i),
+						Rule:    "exists",
+						Message: fmt.Sprintf("actor %d does not exist", actorID),
+					})
+				}
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.InvalidFields(fields))
+			return
+		}
+
+		if err := movieUpdater.UpdateMovie(id, &newMovie, audit.FromRequest(r)); err != nil {
+			if errors.Is(err, storage.ErrVersionConflict) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Movie was modified"))
+				return
+			}
 			log.Error("Failed to update movie", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("Failed to update movie"))
+			status, errResp := resp.StorageError(err, "Failed to update movie")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
 			return
 		}
 
-		render.JSON(w, r, Response{
+		response := Response{
 			Response: resp.Ok(),
 			Movie:    &newMovie,
-		})
+		}
+		// Tagged like GET, so that both tell apart the same representations; movie is updated even if tagging fails
+		if tag, err := etag.Tag(newMovie.Version, response); err != nil {
+			log.Error("Failed to tag movie", sl.Err(err))
+		} else {
+			w.Header().Set("ETag", tag)
+		}
+		render.JSON(w, r, response)
 	}
 }
@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/etag"
+	"github.com/rmntim/movielab/internal/lib/api/patch"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
 	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/update"
 	"github.com/rmntim/movielab/internal/server/handlers/movies/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -23,30 +27,219 @@ var (
 	errMovieGet    = errors.New("failed to get movie")
 )
 
+var validMovie = &entity.Movie{
+	ID: 1,
+	NewMovie: entity.NewMovie{
+		Title:       "Test",
+		Description: "Test",
+		ReleaseDate: time.Now(),
+		Rating:      1,
+		ActorIDs:    []int32{1},
+	},
+}
+
+// storedMovie is returned by GetMovieById
+var storedMovie = entity.NewMovie{
+	Title:       "Alien",
+	Description: "In space no one can hear you scream",
+	ReleaseDate: time.Date(1979, 5, 25, 0, 0, 0, 0, time.UTC),
+	Rating:      8,
+	ActorIDs:    []int32{1, 2},
+	Runtime:     117,
+}
+
 func TestMovieUpdate(t *testing.T) {
 	tests := []struct {
-		name      string
-		id        string
-		reqMovie  *entity.Movie
-		role      string
-		respCode  int
-		respError string
-		mockError error
+		name     string
+		id       string
+		reqMovie *entity.Movie
+		// method is PUT by default
+		method string
+		// body is sent instead of reqMovie when set
+		body string
+		// contentType is application/json by default
+		contentType string
+		role        string
+		respCode    int
+		respError   string
+		mockError   error
+		ifMatch     string
+		// requireIfMatch is passed to handler
+		requireIfMatch bool
+		// tagged tells if response carries ETag of updated version
+		tagged bool
+		// updated is expected to be passed to UpdateMovie when set
+		updated *entity.NewMovie
+		// missing is returned by GetMissingActorIDs
+		missing    []int32
+		respFields []resp.FieldError
 	}{
 		{
-			name: "Success",
-			id:   "1",
-			reqMovie: &entity.Movie{
-				ID: 1,
-				NewMovie: entity.NewMovie{
-					Title:       "Test",
-					Description: "Test",
-					ReleaseDate: time.Now(),
-					Rating:      1,
-					ActorIDs:    []int32{1},
-				},
+			name:     "Success",
+			id:       "1",
+			reqMovie: validMovie,
+			respCode: http.StatusOK,
+			tagged:   true,
+		},
+		{
+			name:     "Put replaces all fields",
+			id:       "1",
+			body:     `{"title":"Aliens","release_date":"1986-07-18T00:00:00Z","rating":9,"actor_ids":[3]}`,
+			respCode: http.StatusOK,
+			tagged:   true,
+			updated: &entity.NewMovie{
+				Title:       "Aliens",
+				ReleaseDate: time.Date(1986, 7, 18, 0, 0, 0, 0, time.UTC),
+				Rating:      9,
+				ActorIDs:    []int32{3},
+			},
+		},
+		{
+			name:      "Put without title",
+			id:        "1",
+			body:      `{"rating":9}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Title is required",
+		},
+		{
+			name:      "Put with rating out of range",
+			id:        "1",
+			body:      `{"title":"Aliens","rating":42}`,
+			respCode:  http.StatusBadRequest,
+			respError: "field Rating is invalid",
+			respFields: []resp.FieldError{
+				{Field: "rating", Rule: "max", Param: "10", Message: "must be at most 10"},
 			},
+		},
+		{
+			name:        "Patch with missing actor",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"actor_ids":[9,1]}`,
+			contentType: patch.MergePatch,
+			missing:     []int32{9},
+			respCode:    http.StatusBadRequest,
+			respError:   "field actor_ids[0] is invalid",
+			respFields: []resp.FieldError{
+				{Field: "actor_ids[0]", Rule: "exists", Message: "actor 9 does not exist"},
+			},
+		},
+		{
+			name:        "Put of unsupported type",
+			id:          "1",
+			reqMovie:    validMovie,
+			contentType: "text/plain",
+			respCode:    http.StatusUnsupportedMediaType,
+			respError:   "Unsupported content type",
+		},
+		{
+			name:        "Merge patch",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"description":null,"rating":9}`,
+			contentType: patch.MergePatch,
+			respCode:    http.StatusOK,
+			tagged:      true,
+			updated: &entity.NewMovie{
+				Title:       storedMovie.Title,
+				ReleaseDate: storedMovie.ReleaseDate,
+				Rating:      9,
+				ActorIDs:    storedMovie.ActorIDs,
+				Runtime:     storedMovie.Runtime,
+			},
+		},
+		{
+			name:        "Merge patch clearing title",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"title":null}`,
+			contentType: patch.MergePatch,
+			respCode:    http.StatusBadRequest,
+			respError:   "field Title is required",
+		},
+		{
+			name:        "JSON patch of actor ids",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `[{"op":"remove","path":"/actor_ids/0"},{"op":"add","path":"/actor_ids/-","value":7}]`,
+			contentType: patch.JSONPatch,
+			respCode:    http.StatusOK,
+			tagged:      true,
+			updated: &entity.NewMovie{
+				Title:       storedMovie.Title,
+				Description: storedMovie.Description,
+				ReleaseDate: storedMovie.ReleaseDate,
+				Rating:      storedMovie.Rating,
+				ActorIDs:    []int32{2, 7},
+				Runtime:     storedMovie.Runtime,
+			},
+		},
+		{
+			name:        "JSON patch failed test",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `[{"op":"test","path":"/title","value":"Aliens"},{"op":"remove","path":"/description"}]`,
+			contentType: patch.JSONPatch,
+			respCode:    http.StatusConflict,
+			respError:   "Patch does not apply",
+		},
+		{
+			name:        "Malformed patch",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `[{"op":"remove"}]`,
+			contentType: patch.JSONPatch,
+			respCode:    http.StatusBadRequest,
+			respError:   "Invalid patch",
+		},
+		{
+			name:        "Patch of plain JSON",
+			id:          "1",
+			method:      http.MethodPatch,
+			body:        `{"rating":9}`,
+			contentType: "application/json",
+			respCode:    http.StatusUnsupportedMediaType,
+			respError:   "Unsupported content type",
+		},
+		{
+			name:     "Success with If-Match",
+			id:       "1",
+			reqMovie: validMovie,
+			ifMatch:  `"3"`,
 			respCode: http.StatusOK,
+			tagged:   true,
+		},
+		{
+			name:      "If-Match mismatch",
+			id:        "1",
+			reqMovie:  validMovie,
+			ifMatch:   `"2"`,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+		},
+		{
+			name:           "If-Match required",
+			id:             "1",
+			reqMovie:       validMovie,
+			requireIfMatch: true,
+			respCode:       http.StatusPreconditionRequired,
+			respError:      "If-Match header is required",
+		},
+		{
+			name:      "Concurrent update",
+			id:        "1",
+			reqMovie:  validMovie,
+			respCode:  http.StatusPreconditionFailed,
+			respError: "Movie was modified",
+			mockError: storage.ErrVersionConflict,
+		},
+		{
+			name:      "Deleted meanwhile",
+			id:        "1",
+			reqMovie:  validMovie,
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
 		},
 		{
 			name:      "Bad Id",
@@ -63,35 +256,17 @@ func TestMovieUpdate(t *testing.T) {
 			respError: "Insufficient permissions",
 		},
 		{
-			name: "GetMovieById Error",
-			id:   "1",
-			reqMovie: &entity.Movie{
-				ID: 1,
-				NewMovie: entity.NewMovie{
-					Title:       "Test",
-					Description: "Test",
-					ReleaseDate: time.Now(),
-					Rating:      1,
-					ActorIDs:    []int32{1},
-				},
-			},
+			name:      "GetMovieById Error",
+			id:        "1",
+			reqMovie:  validMovie,
 			respCode:  http.StatusInternalServerError,
 			respError: "Failed to get movie",
 			mockError: errMovieGet,
 		},
 		{
-			name: "UpdateMovie Error",
-			id:   "1",
-			reqMovie: &entity.Movie{
-				ID: 1,
-				NewMovie: entity.NewMovie{
-					Title:       "Test",
-					Description: "Test",
-					ReleaseDate: time.Now(),
-					Rating:      1,
-					ActorIDs:    []int32{1},
-				},
-			},
+			name:      "UpdateMovie Error",
+			id:        "1",
+			reqMovie:  validMovie,
 			respCode:  http.StatusInternalServerError,
 			respError: "Failed to update movie",
 			mockError: errMovieUpdate,
@@ -105,24 +280,49 @@ func TestMovieUpdate(t *testing.T) {
 
 			movieUpdaterMock := mocks.NewMovieUpdater(t)
 
-			if tt.respError == "" || tt.mockError != nil {
-				if errors.Is(tt.mockError, errMovieGet) {
-					movieUpdaterMock.On("GetMovieById", mock.AnythingOfType("int")).Return(&entity.Movie{}, tt.mockError).Maybe()
-				} else {
-					movieUpdaterMock.On("GetMovieById", mock.AnythingOfType("int")).Return(&entity.Movie{}, nil).Maybe()
-					movieUpdaterMock.On("UpdateMovie", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.Movie")).Return(tt.mockError).Maybe()
-				}
+			missing := tt.missing
+			if missing == nil {
+				missing = []int32{}
 			}
+			movieUpdaterMock.On("GetMissingActorIDs", mock.AnythingOfType("[]int32")).Return(missing, nil).Maybe()
 
-			handler := update.New(slogdiscard.NewDiscardLogger(), movieUpdaterMock)
+			var updated entity.NewMovie
+			if errors.Is(tt.mockError, errMovieGet) {
+				movieUpdaterMock.On("GetMovieById", mock.AnythingOfType("int"), []string(nil)).Return(&entity.Movie{}, tt.mockError).Maybe()
+			} else {
+				movieUpdaterMock.On("GetMovieById", mock.AnythingOfType("int"), []string(nil)).Return(&entity.Movie{NewMovie: storedMovie, Version: 3}, nil).Maybe()
+				movieUpdaterMock.On("UpdateMovie", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.Movie"), entity.AuditInfo{Username: "admin", RequestID: "req-1"}).
+					Run(func(args mock.Arguments) {
+						movie := args.Get(1).(*entity.Movie)
+						updated = movie.NewMovie
+						movie.Version++
+					}).
+					Return(tt.mockError).
+					Maybe()
+			}
 
-			input, err := json.Marshal(tt.reqMovie)
-			require.NoError(t, err)
+			handler := update.New(slogdiscard.NewDiscardLogger(), movieUpdaterMock, tt.requireIfMatch)
+
+			input := []byte(tt.body)
+			if tt.body == "" {
+				var err error
+				input, err = json.Marshal(tt.reqMovie)
+				require.NoError(t, err)
+			}
+
+			method := http.MethodPut
+			if tt.method != "" {
+				method = tt.method
+			}
+			contentType := "application/json"
+			if tt.contentType != "" {
+				contentType = tt.contentType
+			}
 
 			mux := http.NewServeMux()
-			mux.HandleFunc("PUT /{id}", handler)
+			mux.HandleFunc("/{id}", handler)
 
-			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s", tt.id), bytes.NewBuffer(input))
+			req, err := http.NewRequest(method, fmt.Sprintf("/%s", tt.id), bytes.NewBuffer(input))
 			require.NoError(t, err)
 
 			role := "admin"
@@ -130,14 +330,36 @@ func TestMovieUpdate(t *testing.T) {
 				role = tt.role
 			}
 			req.Header.Set("x-role", role)
+			req.Header.Set("x-username", "admin")
+			req.Header.Set("X-Request-Id", "req-1")
+			req.Header.Set("Content-Type", contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			rr := httptest.NewRecorder()
 			mux.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, tt.respCode)
+			require.Equal(t, tt.respCode, rr.Code)
 			body := rr.Body.String()
 			var resp update.Response
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 			require.Equal(t, tt.respError, resp.Error)
+			if tt.tagged {
+				tag, err := etag.Tag(4, resp)
+				require.NoError(t, err)
+				require.Equal(t, tag, rr.Header().Get("ETag"))
+			} else {
+				require.Empty(t, rr.Header().Get("ETag"))
+			}
+			if tt.respFields != nil {
+				require.Equal(t, tt.respFields, resp.Fields)
+			}
+			if tt.updated != nil {
+				require.Equal(t, *tt.updated, updated)
+			}
+			if tt.respCode == http.StatusUnsupportedMediaType && method == http.MethodPatch {
+				require.Equal(t, patch.Accepted, rr.Header().Get("Accept-Patch"))
+			}
 		})
 	}
 }
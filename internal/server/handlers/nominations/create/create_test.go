@@ -0,0 +1,123 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/create"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNominationCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		reqNomination *entity.NewNomination
+		role          string
+		respCode      int
+		respError     string
+		mockError     error
+	}{
+		{
+			name:          "Success movie",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 1, MovieID: 1, Won: true},
+			respCode:      http.StatusOK,
+		},
+		{
+			name:          "Success actor in movie",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 2, MovieID: 1, ActorID: 1},
+			respCode:      http.StatusOK,
+		},
+		{
+			name:          "No nominee",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 1},
+			respCode:      http.StatusBadRequest,
+			respError:     "field MovieID is invalid, field ActorID is invalid",
+		},
+		{
+			name:          "Missing ceremony",
+			reqNomination: &entity.NewNomination{CategoryID: 1, MovieID: 1},
+			respCode:      http.StatusBadRequest,
+			respError:     "field CeremonyID is required",
+		},
+		{
+			name:      "Unauthorized",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:          "Category of another award",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 3, MovieID: 1},
+			respCode:      http.StatusNotFound,
+			respError:     "Award category not found",
+			mockError:     storage.ErrAwardCategoryNotFound,
+		},
+		{
+			name:          "Movie not found",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 1, MovieID: 1},
+			respCode:      http.StatusNotFound,
+			respError:     "Movie not found",
+			mockError:     storage.ErrMovieNotFound,
+		},
+		{
+			name:          "Actor not found",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 2, ActorID: 1},
+			respCode:      http.StatusNotFound,
+			respError:     "Actor not found",
+			mockError:     storage.ErrActorNotFound,
+		},
+		{
+			name:          "CreateNomination error",
+			reqNomination: &entity.NewNomination{CeremonyID: 1, CategoryID: 1, MovieID: 1},
+			respCode:      http.StatusInternalServerError,
+			respError:     "Failed to create nomination",
+			mockError:     errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nominationCreatorMock := mocks.NewNominationCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				nominationCreatorMock.On("CreateNomination", tt.reqNomination).Return(1, tt.mockError).Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), nominationCreatorMock)
+
+			input, err := json.Marshal(tt.reqNomination)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respError == "" {
+				require.Equal(t, 1, resp.ID)
+			}
+		})
+	}
+}
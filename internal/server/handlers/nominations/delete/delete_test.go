@@ -0,0 +1,96 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNominationDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		role      string
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Unauthorized",
+			id:        "1",
+			role:      "user",
+			respCode:  http.StatusUnauthorized,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "Nomination not found",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Nomination not found",
+			mockError: storage.ErrNominationNotFound,
+		},
+		{
+			name:      "DeleteNomination error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to delete nomination",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nominationDeleterMock := mocks.NewNominationDeleter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				nominationDeleterMock.On("DeleteNomination", 1).Return(tt.mockError).Once()
+			}
+
+			handler := delete.New(slogdiscard.NewDiscardLogger(), nominationDeleterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s", tt.id), nil)
+			require.NoError(t, err)
+
+			role := "admin"
+			if tt.role != "" {
+				role = tt.role
+			}
+			req.Header.Set("x-role", role)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
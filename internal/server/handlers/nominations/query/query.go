@@ -0,0 +1,107 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=NominationGetter
+type NominationGetter interface {
+	GetNominations(filter *entity.NominationFilter, limit, offset int) ([]entity.Nomination, error)
+}
+
+type Response struct {
+	resp.Response
+	Nominations []entity.Nomination `json:"nominations"`
+}
+
+func New(log *slog.Logger, nominationGetter NominationGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.nominations.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		var filter entity.NominationFilter
+		ids := []struct {
+			param string
+			dst   *int
+		}{
+			{"award_id", &filter.AwardID},
+			{"ceremony_id", &filter.CeremonyID},
+			{"category_id", &filter.CategoryID},
+			{"movie_id", &filter.MovieID},
+			{"actor_id", &filter.ActorID},
+		}
+		for _, id := range ids {
+			value := r.URL.Query().Get(id.param)
+			if value == "" {
+				continue
+			}
+			*id.dst, err = strconv.Atoi(value)
+			if err != nil {
+				log.Error("Failed to parse "+id.param, sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse "+id.param))
+				return
+			}
+		}
+		if won := r.URL.Query().Get("won"); won != "" {
+			filter.Won, err = strconv.ParseBool(won)
+			if err != nil {
+				log.Error("Failed to parse won", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse won"))
+				return
+			}
+		}
+
+		nominations, err := nominationGetter.GetNominations(&filter, limit, offset)
+		if err != nil {
+			log.Error("Failed to get nominations", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get nominations")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if nominations == nil {
+			nominations = []entity.Nomination{}
+		}
+
+		render.JSON(w, r, Response{
+			Response:    resp.Ok(),
+			Nominations: nominations,
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/query"
+	"github.com/rmntim/movielab/internal/server/handlers/nominations/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNominationQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		mockFilter *entity.NominationFilter
+		mockLimit  int
+		mockOffset int
+		respBody   []entity.Nomination
+		respCode   int
+		respError  string
+		mockError  error
+	}{
+		{
+			name:       "Success",
+			mockFilter: &entity.NominationFilter{},
+			mockLimit:  10,
+			respBody:   []entity.Nomination{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:       "Success with filter",
+			query:      "award_id=1&ceremony_id=2&category_id=3&movie_id=4&actor_id=5&won=true&limit=5&offset=10",
+			mockFilter: &entity.NominationFilter{AwardID: 1, CeremonyID: 2, CategoryID: 3, MovieID: 4, ActorID: 5, Won: true},
+			mockLimit:  5,
+			mockOffset: 10,
+			respBody:   []entity.Nomination{},
+			respCode:   http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "Bad category id",
+			query:     "category_id=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse category_id",
+		},
+		{
+			name:      "Bad won",
+			query:     "won=maybe",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse won",
+		},
+		{
+			name:       "GetNominations error",
+			mockFilter: &entity.NominationFilter{},
+			mockLimit:  10,
+			respCode:   http.StatusInternalServerError,
+			respError:  "Failed to get nominations",
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nominationGetterMock := mocks.NewNominationGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				nominationGetterMock.
+					On("GetNominations", tt.mockFilter, tt.mockLimit, tt.mockOffset).
+					Return(nil, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), nominationGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Nominations)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
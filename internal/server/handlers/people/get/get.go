@@ -0,0 +1,51 @@
+package get
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=PersonByIdGetter
+type PersonByIdGetter interface {
+	GetPersonById(id int) (*entity.Person, error)
+}
+
+type Response struct {
+	resp.Response
+	Person *entity.Person `json:"person"`
+}
+
+func New(log *slog.Logger, personByIdGetter PersonByIdGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.people.get.New"
+
+		log := log.With(slog.String("op", op))
+
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse id"))
+			return
+		}
+
+		person, err := personByIdGetter.GetPersonById(id)
+		if err != nil {
+			log.Error("Failed to get person", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get person")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Person:   person,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/people/get"
+	"github.com/rmntim/movielab/internal/server/handlers/people/get/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPeopleGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		respBody  *entity.Person
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			id:   "1",
+			respBody: &entity.Person{
+				ID:       1,
+				MovieIDs: []int32{1},
+				Credits:  []entity.Credit{{MovieID: 2, Role: entity.CrewRoleDirector}},
+			},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse id",
+		},
+		{
+			name:      "GetPersonById error",
+			id:        "1",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get person",
+			mockError: errors.New("failed to get person"),
+		},
+		{
+			name:      "Person not found error",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Person not found",
+			mockError: storage.ErrPersonNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			personByIdGetterMock := mocks.NewPersonByIdGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				personByIdGetterMock.
+					On("GetPersonById", mock.AnythingOfType("int")).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := get.New(slogdiscard.NewDiscardLogger(), personByIdGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/{id}", handler)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s", tt.id), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp get.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Person)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=PeopleGetter
+type PeopleGetter interface {
+	GetPeople(limit, offset int, role string) ([]entity.Person, error)
+}
+
+type Response struct {
+	resp.Response
+	People []entity.Person `json:"people"`
+}
+
+func New(log *slog.Logger, peopleGetter PeopleGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.people.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		role := r.URL.Query().Get("role")
+
+		people, err := peopleGetter.GetPeople(limit, offset, role)
+		if err != nil {
+			log.Error("Failed to get people", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get people")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if people == nil {
+			people = []entity.Person{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			People:   people,
+		})
+	}
+}
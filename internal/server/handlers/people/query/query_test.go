@@ -0,0 +1,98 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/people/query"
+	"github.com/rmntim/movielab/internal/server/handlers/people/query/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPeopleQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     string
+		offset    string
+		role      string
+		respBody  []entity.Person
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			limit:    "10",
+			offset:   "0",
+			respBody: []entity.Person{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Success with role",
+			limit:    "10",
+			offset:   "0",
+			role:     "director",
+			respBody: []entity.Person{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			limit:     "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			offset:    "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetPeople error",
+			limit:     "10",
+			offset:    "0",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get people",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			peopleGetterMock := mocks.NewPeopleGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				peopleGetterMock.
+					On("GetPeople", mock.AnythingOfType("int"), mock.AnythingOfType("int"), tt.role).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), peopleGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet,
+				fmt.Sprintf("/?limit=%s&offset=%s&role=%s", tt.limit, tt.offset, tt.role),
+				nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.People)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
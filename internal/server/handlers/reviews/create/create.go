@@ -0,0 +1,71 @@
+package create
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ReviewCreator
+type ReviewCreator interface {
+	CreateReview(movieID int, username string, review *entity.NewReview) (*entity.Review, error)
+}
+
+type Response struct {
+	resp.Response
+	Review *entity.Review `json:"review"`
+}
+
+func New(log *slog.Logger, reviewCreator ReviewCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reviews.create.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		var review entity.NewReview
+		if err := render.DecodeJSON(r.Body, &review); err != nil {
+			log.Error("Failed to decode request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Invalid request"))
+			return
+		}
+
+		if err := validate.Struct(review); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		created, err := reviewCreator.CreateReview(movieID, r.Header.Get("x-username"), &review)
+		if err != nil {
+			log.Error("Failed to create review", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to create review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Review:   created,
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/create"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/create/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReviewCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		reqReview *entity.NewReview
+		respCode  int
+		respError string
+		// respField is request path of invalid field
+		respField string
+		mockError error
+	}{
+		{
+			name:      "Success",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 7, Text: "Test"},
+			respCode:  http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			reqReview: &entity.NewReview{Rating: 7},
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Bad rating",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 11},
+			respCode:  http.StatusBadRequest,
+			respError: "field Rating is invalid",
+			respField: "rating",
+		},
+		{
+			name:      "Review exists",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 7},
+			respCode:  http.StatusConflict,
+			respError: "Review already exists",
+			mockError: storage.ErrReviewExists,
+		},
+		{
+			name:      "Movie not found",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 7},
+			respCode:  http.StatusNotFound,
+			respError: "Movie not found",
+			mockError: storage.ErrMovieNotFound,
+		},
+		{
+			name:      "CreateReview error",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 7},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to create review",
+			mockError: errors.New("failed to create review"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewCreatorMock := mocks.NewReviewCreator(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				reviewCreatorMock.
+					On("CreateReview", mock.AnythingOfType("int"), "user", mock.AnythingOfType("*entity.NewReview")).
+					Return(&entity.Review{}, tt.mockError).
+					Once()
+			}
+
+			handler := create.New(slogdiscard.NewDiscardLogger(), reviewCreatorMock)
+
+			input, err := json.Marshal(tt.reqReview)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /{id}/reviews", handler)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/reviews", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-role", "user")
+			req.Header.Set("x-username", "user")
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp create.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+			if tt.respField != "" {
+				require.Len(t, resp.Fields, 1)
+				require.Equal(t, tt.respField, resp.Fields[0].Field)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package delete
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ReviewDeleter
+type ReviewDeleter interface {
+	GetReviewById(id int) (*entity.Review, error)
+	DeleteReview(id int) error
+}
+
+// New creates handler deleting review. Review can be deleted by its author or admin.
+func New(log *slog.Logger, reviewDeleter ReviewDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reviews.delete.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("review_id"))
+		if err != nil {
+			log.Error("Failed to parse review id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse review id"))
+			return
+		}
+
+		review, err := reviewDeleter.GetReviewById(id)
+		if err != nil {
+			log.Error("Failed to get review", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if review.MovieID != movieID {
+			status, errResp := resp.StorageError(storage.ErrReviewNotFound, "Failed to get review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if r.Header.Get("x-role") != "admin" && review.Username != r.Header.Get("x-username") {
+			log.Error("Insufficient permissions", slog.String("username", r.Header.Get("x-username")))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		if err := reviewDeleter.DeleteReview(id); err != nil {
+			log.Error("Failed to delete review", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to delete review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, resp.Ok())
+	}
+}
@@ -0,0 +1,123 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	reviewsDelete "github.com/rmntim/movielab/internal/server/handlers/reviews/delete"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/delete/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReviewDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		role      string
+		username  string
+		review    *entity.Review
+		respCode  int
+		respError string
+		getError  error
+		mockError error
+	}{
+		{
+			name:     "Success by author",
+			id:       "1",
+			role:     "user",
+			username: "user",
+			review:   &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Success by admin",
+			id:       "1",
+			role:     "admin",
+			username: "admin",
+			review:   &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad review id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse review id",
+		},
+		{
+			name:      "Review not found",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Review not found",
+			getError:  storage.ErrReviewNotFound,
+		},
+		{
+			name:      "Not an author",
+			id:        "1",
+			role:      "user",
+			username:  "other",
+			review:    &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusForbidden,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Deleted meanwhile",
+			id:        "1",
+			role:      "admin",
+			review:    &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusNotFound,
+			respError: "Review not found",
+			mockError: storage.ErrReviewNotFound,
+		},
+		{
+			name:      "DeleteReview error",
+			id:        "1",
+			role:      "admin",
+			review:    &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to delete review",
+			mockError: errors.New("failed to delete review"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewDeleterMock := mocks.NewReviewDeleter(t)
+
+			if tt.review != nil || tt.getError != nil {
+				reviewDeleterMock.On("GetReviewById", mock.AnythingOfType("int")).Return(tt.review, tt.getError).Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				reviewDeleterMock.On("DeleteReview", mock.AnythingOfType("int")).Return(tt.mockError).Once()
+			}
+
+			handler := reviewsDelete.New(slogdiscard.NewDiscardLogger(), reviewDeleterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /{id}/reviews/{review_id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/1/reviews/%s", tt.id), nil)
+			require.NoError(t, err)
+			req.Header.Set("x-role", tt.role)
+			req.Header.Set("x-username", tt.username)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
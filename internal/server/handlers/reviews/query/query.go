@@ -0,0 +1,80 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ReviewGetter
+type ReviewGetter interface {
+	GetReviews(movieID, limit, offset int) ([]entity.Review, error)
+}
+
+type Response struct {
+	resp.Response
+	Reviews []entity.Review `json:"reviews"`
+}
+
+func New(log *slog.Logger, reviewGetter ReviewGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reviews.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		reviews, err := reviewGetter.GetReviews(movieID, limit, offset)
+		if err != nil {
+			log.Error("Failed to get reviews", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get reviews")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if reviews == nil {
+			reviews = []entity.Review{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Reviews:  reviews,
+		})
+	}
+}
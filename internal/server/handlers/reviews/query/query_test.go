@@ -0,0 +1,103 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/query"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/query/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReviewQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		limit     string
+		offset    string
+		respBody  []entity.Review
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			id:       "1",
+			limit:    "10",
+			offset:   "0",
+			respBody: []entity.Review{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse movie id",
+		},
+		{
+			name:      "Bad limit",
+			id:        "1",
+			limit:     "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			id:        "1",
+			offset:    "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetReviews error",
+			id:        "1",
+			limit:     "10",
+			offset:    "0",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get reviews",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewGetterMock := mocks.NewReviewGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				reviewGetterMock.
+					On("GetReviews", mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), reviewGetterMock)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /{id}/reviews", handler)
+
+			req, err := http.NewRequest(http.MethodGet,
+				fmt.Sprintf("/%s/reviews?limit=%s&offset=%s", tt.id, tt.limit, tt.offset),
+				nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Reviews)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
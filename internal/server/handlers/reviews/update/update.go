@@ -0,0 +1,104 @@
+package update
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/api/validate"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"github.com/rmntim/movielab/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ReviewUpdater
+type ReviewUpdater interface {
+	GetReviewById(id int) (*entity.Review, error)
+	UpdateReview(id int, review *entity.NewReview) (*entity.Review, error)
+}
+
+type Response struct {
+	resp.Response
+	Review *entity.Review `json:"review"`
+}
+
+// New creates handler updating review. Only the author can edit their review.
+func New(log *slog.Logger, reviewUpdater ReviewUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reviews.update.New"
+
+		log := log.With(slog.String("op", op))
+
+		movieID, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			log.Error("Failed to parse movie id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse movie id"))
+			return
+		}
+
+		id, err := strconv.Atoi(r.PathValue("review_id"))
+		if err != nil {
+			log.Error("Failed to parse review id", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse review id"))
+			return
+		}
+
+		oldReview, err := reviewUpdater.GetReviewById(id)
+		if err != nil {
+			log.Error("Failed to get review", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if oldReview.MovieID != movieID {
+			status, errResp := resp.StorageError(storage.ErrReviewNotFound, "Failed to get review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		if oldReview.Username != r.Header.Get("x-username") {
+			log.Error("Insufficient permissions", slog.String("username", r.Header.Get("x-username")))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, resp.Error("Insufficient permissions"))
+			return
+		}
+
+		newReview := oldReview.NewReview
+		if err := render.DecodeJSON(r.Body, &newReview); err != nil {
+			log.Error("Failed to parse body", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("Failed to parse body"))
+			return
+		}
+
+		if err := validate.Struct(newReview); err != nil {
+			log.Error("Invalid request", sl.Err(err))
+			w.WriteHeader(http.StatusBadRequest)
+			var validationErr validator.ValidationErrors
+			errors.As(err, &validationErr)
+			render.JSON(w, r, resp.ValidationError(validationErr))
+			return
+		}
+
+		updated, err := reviewUpdater.UpdateReview(id, &newReview)
+		if err != nil {
+			log.Error("Failed to update review", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to update review")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Review:   updated,
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/update"
+	"github.com/rmntim/movielab/internal/server/handlers/reviews/update/mocks"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errReviewUpdate = errors.New("failed to update review")
+
+func TestReviewUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		reqReview *entity.NewReview
+		username  string
+		oldReview *entity.Review
+		respCode  int
+		respError string
+		getError  error
+		mockError error
+	}{
+		{
+			name:      "Success",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 8},
+			username:  "user",
+			oldReview: &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusOK,
+		},
+		{
+			name:      "Bad review id",
+			id:        "a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse review id",
+		},
+		{
+			name:      "Review not found",
+			id:        "1",
+			respCode:  http.StatusNotFound,
+			respError: "Review not found",
+			getError:  storage.ErrReviewNotFound,
+		},
+		{
+			name:      "Review of another movie",
+			id:        "1",
+			username:  "user",
+			oldReview: &entity.Review{ID: 1, MovieID: 2, Username: "user"},
+			respCode:  http.StatusNotFound,
+			respError: "Review not found",
+		},
+		{
+			name:      "Not an author",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 8},
+			username:  "admin",
+			oldReview: &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusForbidden,
+			respError: "Insufficient permissions",
+		},
+		{
+			name:      "Bad rating",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: -1},
+			username:  "user",
+			oldReview: &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusBadRequest,
+			respError: "field Rating is invalid",
+		},
+		{
+			name:      "UpdateReview error",
+			id:        "1",
+			reqReview: &entity.NewReview{Rating: 8},
+			username:  "user",
+			oldReview: &entity.Review{ID: 1, MovieID: 1, Username: "user"},
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to update review",
+			mockError: errReviewUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewUpdaterMock := mocks.NewReviewUpdater(t)
+
+			if tt.oldReview != nil || tt.getError != nil {
+				reviewUpdaterMock.On("GetReviewById", mock.AnythingOfType("int")).Return(tt.oldReview, tt.getError).Once()
+			}
+			if tt.respError == "" || tt.mockError != nil {
+				reviewUpdaterMock.
+					On("UpdateReview", mock.AnythingOfType("int"), mock.AnythingOfType("*entity.NewReview")).
+					Return(&entity.Review{}, tt.mockError).
+					Once()
+			}
+
+			handler := update.New(slogdiscard.NewDiscardLogger(), reviewUpdaterMock)
+
+			input, err := json.Marshal(tt.reqReview)
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /{id}/reviews/{review_id}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/1/reviews/%s", tt.id), bytes.NewReader(input))
+			require.NoError(t, err)
+			req.Header.Set("x-role", "user")
+			req.Header.Set("x-username", tt.username)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp update.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
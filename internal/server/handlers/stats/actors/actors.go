@@ -0,0 +1,69 @@
+package actors
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ProlificActorsGetter
+type ProlificActorsGetter interface {
+	GetProlificActors(limit int) ([]entity.ProlificActor, error)
+}
+
+type Response struct {
+	resp.Response
+	Actors []entity.ProlificActor `json:"actors"`
+}
+
+func New(log *slog.Logger, prolificActorsGetter ProlificActorsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stats.actors.New"
+
+		log := log.With(slog.String("op", op))
+
+		limit := defaultLimit
+		var err error
+
+		// Limit is capped since statistics are cached by it
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+			if limit < 1 || limit > maxLimit {
+				log.Error("Invalid limit", slog.Int("limit", limit))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("limit must be between 1 and 100"))
+				return
+			}
+		}
+
+		actors, err := prolificActorsGetter.GetProlificActors(limit)
+		if err != nil {
+			log.Error("Failed to get prolific actors", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get prolific actors")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Actors:   actors,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package actors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/actors"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/actors/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProlificActors(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		limit     int
+		respBody  []entity.ProlificActor
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			limit:    10,
+			respBody: []entity.ProlificActor{{ActorSummary: entity.ActorSummary{ID: 1, Name: "A"}, Movies: 5}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Custom limit",
+			query:    "limit=3",
+			limit:    3,
+			respBody: []entity.ProlificActor{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:     "Max limit",
+			query:    "limit=100",
+			limit:    100,
+			respBody: []entity.ProlificActor{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Negative limit",
+			query:     "limit=-1",
+			respCode:  http.StatusBadRequest,
+			respError: "limit must be between 1 and 100",
+		},
+		{
+			name:      "Zero limit",
+			query:     "limit=0",
+			respCode:  http.StatusBadRequest,
+			respError: "limit must be between 1 and 100",
+		},
+		{
+			name:      "Limit over max",
+			query:     "limit=101",
+			respCode:  http.StatusBadRequest,
+			respError: "limit must be between 1 and 100",
+		},
+		{
+			name:      "GetProlificActors error",
+			limit:     10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get prolific actors",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prolificActorsGetterMock := mocks.NewProlificActorsGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				prolificActorsGetterMock.
+					On("GetProlificActors", tt.limit).
+					Return(tt.respBody, tt.mockError).
+					Once()
+			}
+
+			handler := actors.New(slogdiscard.NewDiscardLogger(), prolificActorsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/?%s", tt.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp actors.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Actors)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ages
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=ActorAgeStatsGetter
+type ActorAgeStatsGetter interface {
+	GetActorAgeStats() (*entity.Summary, error)
+}
+
+type Response struct {
+	resp.Response
+	Ages *entity.Summary `json:"ages"`
+}
+
+func New(log *slog.Logger, actorAgeStatsGetter ActorAgeStatsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stats.ages.New"
+
+		log := log.With(slog.String("op", op))
+
+		ages, err := actorAgeStatsGetter.GetActorAgeStats()
+		if err != nil {
+			log.Error("Failed to get actor age statistics", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get actor age statistics")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Ages:     ages,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ages_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/ages"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/ages/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorAgeStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		respBody  *entity.Summary
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			respBody: &entity.Summary{Count: 10, Average: 35.5, Median: 34, Min: 9, Max: 80},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "GetActorAgeStats error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get actor age statistics",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorAgeStatsGetterMock := mocks.NewActorAgeStatsGetter(t)
+			actorAgeStatsGetterMock.
+				On("GetActorAgeStats").
+				Return(tt.respBody, tt.mockError).
+				Once()
+
+			handler := ages.New(slogdiscard.NewDiscardLogger(), actorAgeStatsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp ages.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Ages)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package cast
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=CastSizeStatsGetter
+type CastSizeStatsGetter interface {
+	GetCastSizeStats() (*entity.Summary, error)
+}
+
+type Response struct {
+	resp.Response
+	CastSize *entity.Summary `json:"cast_size"`
+}
+
+func New(log *slog.Logger, castSizeStatsGetter CastSizeStatsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stats.cast.New"
+
+		log := log.With(slog.String("op", op))
+
+		castSize, err := castSizeStatsGetter.GetCastSizeStats()
+		if err != nil {
+			log.Error("Failed to get cast size statistics", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get cast size statistics")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			CastSize: castSize,
+		})
+	}
+}
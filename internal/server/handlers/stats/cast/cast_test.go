@@ -0,0 +1,64 @@
+package cast_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/cast"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/cast/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCastSizeStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		respBody  *entity.Summary
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			respBody: &entity.Summary{Count: 3, Average: 2.5, Median: 2, Min: 1, Max: 5},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "GetCastSizeStats error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get cast size statistics",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			castSizeStatsGetterMock := mocks.NewCastSizeStatsGetter(t)
+			castSizeStatsGetterMock.
+				On("GetCastSizeStats").
+				Return(tt.respBody, tt.mockError).
+				Once()
+
+			handler := cast.New(slogdiscard.NewDiscardLogger(), castSizeStatsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp cast.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.CastSize)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ratings
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=RatingDistributionGetter
+type RatingDistributionGetter interface {
+	GetRatingDistribution() (*entity.RatingDistribution, error)
+}
+
+type Response struct {
+	resp.Response
+	Ratings *entity.RatingDistribution `json:"ratings"`
+}
+
+func New(log *slog.Logger, ratingDistributionGetter RatingDistributionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stats.ratings.New"
+
+		log := log.With(slog.String("op", op))
+
+		ratings, err := ratingDistributionGetter.GetRatingDistribution()
+		if err != nil {
+			log.Error("Failed to get rating distribution", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get rating distribution")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Ratings:  ratings,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ratings_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/ratings"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/ratings/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRatingDistribution(t *testing.T) {
+	tests := []struct {
+		name      string
+		respBody  *entity.RatingDistribution
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			respBody: &entity.RatingDistribution{Movies: []entity.RatingCount{{Rating: 8, Count: 1}}, Reviews: []entity.RatingCount{{Rating: 10, Count: 3}}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "GetRatingDistribution error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get rating distribution",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ratingDistributionGetterMock := mocks.NewRatingDistributionGetter(t)
+			ratingDistributionGetterMock.
+				On("GetRatingDistribution").
+				Return(tt.respBody, tt.mockError).
+				Once()
+
+			handler := ratings.New(slogdiscard.NewDiscardLogger(), ratingDistributionGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp ratings.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Ratings)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
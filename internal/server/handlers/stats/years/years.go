@@ -0,0 +1,42 @@
+package years
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=MovieCountsGetter
+type MovieCountsGetter interface {
+	GetMovieCountsByYear() ([]entity.YearCount, error)
+}
+
+type Response struct {
+	resp.Response
+	Years []entity.YearCount `json:"years"`
+}
+
+func New(log *slog.Logger, movieCountsGetter MovieCountsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stats.years.New"
+
+		log := log.With(slog.String("op", op))
+
+		years, err := movieCountsGetter.GetMovieCountsByYear()
+		if err != nil {
+			log.Error("Failed to get movies per year", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get movies per year")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Years:    years,
+		})
+	}
+}
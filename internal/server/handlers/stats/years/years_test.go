@@ -0,0 +1,64 @@
+package years_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/years"
+	"github.com/rmntim/movielab/internal/server/handlers/stats/years/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMoviesPerYear(t *testing.T) {
+	tests := []struct {
+		name      string
+		respBody  []entity.YearCount
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			respBody: []entity.YearCount{{Year: 1999, Count: 2}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "GetMovieCountsByYear error",
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get movies per year",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieCountsGetterMock := mocks.NewMovieCountsGetter(t)
+			movieCountsGetterMock.
+				On("GetMovieCountsByYear").
+				Return(tt.respBody, tt.mockError).
+				Once()
+
+			handler := years.New(slogdiscard.NewDiscardLogger(), movieCountsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp years.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Years)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
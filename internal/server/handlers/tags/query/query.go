@@ -0,0 +1,77 @@
+package query
+
+import (
+	"github.com/go-chi/render"
+	"github.com/rmntim/movielab/internal/entity"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.0 --name=TagGetter
+type TagGetter interface {
+	GetTags(prefix string, limit, offset int) ([]entity.Tag, error)
+}
+
+type Response struct {
+	resp.Response
+	Tags []entity.Tag `json:"tags"`
+}
+
+func New(log *slog.Logger, tagGetter TagGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.query.New"
+
+		log := log.With(slog.String("op", op))
+
+		var (
+			limit  = 10
+			offset = 0
+		)
+		var err error
+
+		queryLimit := r.URL.Query().Get("limit")
+		if queryLimit != "" {
+			limit, err = strconv.Atoi(queryLimit)
+			if err != nil {
+				log.Error("Failed to parse limit", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse limit"))
+				return
+			}
+		}
+		queryOffset := r.URL.Query().Get("offset")
+		if queryOffset != "" {
+			offset, err = strconv.Atoi(queryOffset)
+			if err != nil {
+				log.Error("Failed to parse offset", sl.Err(err))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("Failed to parse offset"))
+				return
+			}
+		}
+
+		// Prefix is typed by user, so trailing space is kept to complete the next word
+		prefix := strings.ToLower(strings.TrimLeft(r.URL.Query().Get("prefix"), " "))
+
+		tags, err := tagGetter.GetTags(prefix, limit, offset)
+		if err != nil {
+			log.Error("Failed to get tags", sl.Err(err))
+			status, errResp := resp.StorageError(err, "Failed to get tags")
+			w.WriteHeader(status)
+			render.JSON(w, r, errResp)
+			return
+		}
+		if tags == nil {
+			tags = []entity.Tag{}
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.Ok(),
+			Tags:     tags,
+		})
+	}
+}
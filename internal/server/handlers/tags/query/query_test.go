@@ -0,0 +1,97 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/logger/handlers/slogdiscard"
+	"github.com/rmntim/movielab/internal/server/handlers/tags/query"
+	"github.com/rmntim/movielab/internal/server/handlers/tags/query/mocks"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTagQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		prefix    string
+		limit     int
+		offset    int
+		mockBody  []entity.Tag
+		respBody  []entity.Tag
+		respCode  int
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			limit:    10,
+			mockBody: []entity.Tag{{Name: "cult classic", Movies: 3, Actors: 1}},
+			respBody: []entity.Tag{{Name: "cult classic", Movies: 3, Actors: 1}},
+			respCode: http.StatusOK,
+		},
+		{
+			name:     "Autocomplete",
+			query:    "prefix=%20Cult%20&limit=5&offset=5",
+			prefix:   "cult ",
+			limit:    5,
+			offset:   5,
+			respBody: []entity.Tag{},
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "Bad limit",
+			query:     "limit=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse limit",
+		},
+		{
+			name:      "Bad offset",
+			query:     "offset=a",
+			respCode:  http.StatusBadRequest,
+			respError: "Failed to parse offset",
+		},
+		{
+			name:      "GetTags error",
+			limit:     10,
+			respCode:  http.StatusInternalServerError,
+			respError: "Failed to get tags",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tagGetterMock := mocks.NewTagGetter(t)
+
+			if tt.respError == "" || tt.mockError != nil {
+				tagGetterMock.
+					On("GetTags", tt.prefix, tt.limit, tt.offset).
+					Return(tt.mockBody, tt.mockError).
+					Once()
+			}
+
+			handler := query.New(slogdiscard.NewDiscardLogger(), tagGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/?%s", tt.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respCode, rr.Code)
+			var resp query.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tt.respBody, resp.Tags)
+			require.Equal(t, tt.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package cachecontrol
+
+import (
+	"net/http"
+)
+
+// New creates new middleware setting Cache-Control of successful and not modified responses to policy,
+// error responses are left without it. Empty policy disables the middleware.
+func New(policy string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if policy == "" {
+			return next
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&writer{ResponseWriter: w, policy: policy}, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// writer sets Cache-Control header right before status is written, once it is known
+type writer struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *writer) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code == http.StatusOK || code == http.StatusNotModified {
+			w.Header().Set("Cache-Control", w.policy)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,61 @@
+package cachecontrol_test
+
+import (
+	"github.com/rmntim/movielab/internal/server/middleware/cachecontrol"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheControlNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		// code is written by handler, zero means implicit 200 of body write
+		code       int
+		respPolicy string
+	}{
+		{
+			name:       "Implicit ok",
+			policy:     "private, max-age=60",
+			respPolicy: "private, max-age=60",
+		},
+		{
+			name:       "Not modified",
+			policy:     "private, max-age=60",
+			code:       http.StatusNotModified,
+			respPolicy: "private, max-age=60",
+		},
+		{
+			name:   "Error",
+			policy: "private, max-age=60",
+			code:   http.StatusNotFound,
+		},
+		{
+			name: "No policy",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+
+			handler := cachecontrol.New(tt.policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.code != 0 {
+					w.WriteHeader(tt.code)
+				}
+				_, _ = w.Write([]byte("{}"))
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.respPolicy, rr.Header().Get("Cache-Control"))
+		})
+	}
+}
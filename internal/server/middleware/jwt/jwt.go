@@ -8,7 +8,7 @@ import (
 	"net/http"
 )
 
-// New creates new middleware, sets `x-role` header if user is authorized.
+// New creates new middleware, sets `x-role` and `x-username` headers if user is authorized.
 func New(secret string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
@@ -16,13 +16,14 @@ func New(secret string) func(http.Handler) http.Handler {
 			if token != "" {
 				authorized, err := isAuthorized(token, secret)
 				if authorized {
-					role, err := getUserRole(token, secret)
+					username, role, err := getUserClaims(token, secret)
 					if err != nil {
 						w.WriteHeader(http.StatusUnauthorized)
 						render.JSON(w, r, resp.Error(err.Error()))
 						return
 					}
 					r.Header.Set("x-role", role)
+					r.Header.Set("x-username", username)
 					next.ServeHTTP(w, r)
 					return
 				}
@@ -50,7 +51,7 @@ func isAuthorized(reqToken string, secret string) (bool, error) {
 	return true, nil
 }
 
-func getUserRole(reqToken string, secret string) (string, error) {
+func getUserClaims(reqToken string, secret string) (string, string, error) {
 	token, err := jwt.Parse(reqToken, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -58,11 +59,19 @@ func getUserRole(reqToken string, secret string) (string, error) {
 		return []byte(secret), nil
 	})
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return "", fmt.Errorf("invalid token")
+		return "", "", fmt.Errorf("invalid token")
 	}
-	return claims["role"].(string), nil
+	username, ok := claims["username"].(string)
+	if !ok {
+		return "", "", fmt.Errorf("invalid token")
+	}
+	role, ok := claims["role"].(string)
+	if !ok {
+		return "", "", fmt.Errorf("invalid token")
+	}
+	return username, role, nil
 }
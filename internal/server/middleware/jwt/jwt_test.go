@@ -18,12 +18,14 @@ func TestJwtNew(t *testing.T) {
 	tests := []struct {
 		name       string
 		token      string
+		username   string
 		respStatus int
 		respError  string
 	}{
 		{
 			name:       "Success",
 			token:      generateJwt(t, "admin", "admin", jwtSecret),
+			username:   "admin",
 			respStatus: http.StatusOK,
 		},
 		{
@@ -47,6 +49,7 @@ func TestJwtNew(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.username, r.Header.Get("x-username"))
 				w.WriteHeader(http.StatusOK)
 				render.JSON(w, r, resp.Ok())
 			}))
@@ -0,0 +1,91 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-chi/render"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/server/middleware/requestid"
+	"net/http"
+	"strings"
+)
+
+// New creates new middleware turning error responses of handlers, either resp.Response with error status
+// or plain text ones of http.Error, into problem details, RFC 7807. Other error bodies are left as is.
+func New() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := &writer{ResponseWriter: w}
+			next.ServeHTTP(ww, r)
+
+			if ww.status < http.StatusBadRequest {
+				return
+			}
+
+			problem, ok := toProblem(ww.status, ww.Header().Get("Content-Type"), ww.body.Bytes())
+			if !ok {
+				w.WriteHeader(ww.status)
+				_, _ = ww.body.WriteTo(w)
+				return
+			}
+			problem.Instance = r.URL.Path
+			problem.RequestID = r.Header.Get(requestid.Header)
+
+			body, err := json.Marshal(problem)
+			if err != nil {
+				w.WriteHeader(ww.status)
+				_, _ = ww.body.WriteTo(w)
+				return
+			}
+
+			w.Header().Set("Content-Type", resp.ProblemContentType)
+			w.Header().Del("Content-Length")
+			w.WriteHeader(ww.status)
+			_, _ = w.Write(body)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// toProblem converts error response body, ok is false when body is neither resp.Response nor plain text
+func toProblem(status int, contentType string, body []byte) (resp.Problem, bool) {
+	if strings.HasPrefix(contentType, "text/plain") {
+		return resp.NewProblem(status, "", strings.TrimSpace(string(body))), true
+	}
+
+	var errResp resp.Response
+	if err := render.DecodeJSON(bytes.NewReader(body), &errResp); err != nil || errResp.Status != resp.StatusError {
+		return resp.Problem{}, false
+	}
+
+	problem := resp.NewProblem(status, errResp.Code, errResp.Error)
+	problem.Fields = errResp.Fields
+	return problem, true
+}
+
+// writer passes successful responses through and keeps error ones to be converted
+type writer struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *writer) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	if code < http.StatusBadRequest {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.status >= http.StatusBadRequest {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,144 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"github.com/go-chi/render"
+	resp "github.com/rmntim/movielab/internal/lib/api/response"
+	"github.com/rmntim/movielab/internal/server/middleware/problem"
+	"github.com/rmntim/movielab/internal/server/middleware/requestid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblemNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		code    int
+		// contentType is expected in response
+		contentType string
+		// problem is expected in response when set, otherwise body is expected to be unchanged
+		problem *resp.Problem
+		body    string
+	}{
+		{
+			name: "Success",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				render.JSON(w, r, resp.Ok())
+			},
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        `{"status":"Ok"}`,
+		},
+		{
+			name: "Error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				render.JSON(w, r, resp.Error("Movie not found"))
+			},
+			code:        http.StatusNotFound,
+			contentType: resp.ProblemContentType,
+			problem: &resp.Problem{
+				Type:      "urn:movielab:problem:not_found",
+				Title:     "Not Found",
+				Status:    http.StatusNotFound,
+				Detail:    "Movie not found",
+				Instance:  "/api/movies/1",
+				Code:      resp.CodeNotFound,
+				RequestID: "req-1",
+			},
+		},
+		{
+			name: "Error with code",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				render.JSON(w, r, resp.ErrorCode(resp.CodeVersionConflict, "Movie was modified"))
+			},
+			code:        http.StatusPreconditionFailed,
+			contentType: resp.ProblemContentType,
+			problem: &resp.Problem{
+				Type:      "urn:movielab:problem:version_conflict",
+				Title:     "Precondition Failed",
+				Status:    http.StatusPreconditionFailed,
+				Detail:    "Movie was modified",
+				Instance:  "/api/movies/1",
+				Code:      resp.CodeVersionConflict,
+				RequestID: "req-1",
+			},
+		},
+		{
+			name: "Invalid fields",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.InvalidFields([]resp.FieldError{{Field: "actor_ids[0]", Rule: "exists", Message: "actor 9 does not exist"}}))
+			},
+			code:        http.StatusBadRequest,
+			contentType: resp.ProblemContentType,
+			problem: &resp.Problem{
+				Type:      "urn:movielab:problem:validation_failed",
+				Title:     "Bad Request",
+				Status:    http.StatusBadRequest,
+				Detail:    "field actor_ids[0] is invalid",
+				Instance:  "/api/movies/1",
+				Code:      resp.CodeValidationFailed,
+				RequestID: "req-1",
+				Fields:    []resp.FieldError{{Field: "actor_ids[0]", Rule: "exists", Message: "actor 9 does not exist"}},
+			},
+		},
+		{
+			name: "Plain text error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			},
+			code:        http.StatusMethodNotAllowed,
+			contentType: resp.ProblemContentType,
+			problem: &resp.Problem{
+				Type:      "urn:movielab:problem:method_not_allowed",
+				Title:     "Method Not Allowed",
+				Status:    http.StatusMethodNotAllowed,
+				Detail:    "Method Not Allowed",
+				Instance:  "/api/movies/1",
+				Code:      resp.CodeMethodNotAllowed,
+				RequestID: "req-1",
+			},
+		},
+		{
+			name: "Other error body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"bad"}`))
+			},
+			code:        http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"message":"bad"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, "/api/movies/1", nil)
+			require.NoError(t, err)
+			req.Header.Set(requestid.Header, "req-1")
+
+			rr := httptest.NewRecorder()
+			problem.New()(tt.handler).ServeHTTP(rr, req)
+
+			require.Equal(t, tt.code, rr.Code)
+			require.Contains(t, rr.Header().Get("Content-Type"), tt.contentType)
+			if tt.problem == nil {
+				require.JSONEq(t, tt.body, rr.Body.String())
+				return
+			}
+
+			var problem resp.Problem
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+			require.Equal(t, *tt.problem, problem)
+		})
+	}
+}
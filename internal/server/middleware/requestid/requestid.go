@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header carries id of request, it is echoed in response so clients can refer to the request
+const Header = "X-Request-Id"
+
+// maxLength limits length of id accepted from client, longer ids are replaced
+const maxLength = 128
+
+// New creates new middleware, it keeps request id sent by client or generates a new one.
+func New() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(Header)
+			if id == "" || len(id) > maxLength {
+				id = generate()
+				r.Header.Set(Header, id)
+			}
+			w.Header().Set(Header, id)
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
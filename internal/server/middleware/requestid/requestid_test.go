@@ -0,0 +1,61 @@
+package requestid_test
+
+import (
+	"github.com/rmntim/movielab/internal/server/middleware/requestid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		// kept tells whether request id sent by client is used
+		kept bool
+	}{
+		{
+			name:      "Client id",
+			requestID: "abc-123",
+			kept:      true,
+		},
+		{
+			name: "Generated id",
+		},
+		{
+			name:      "Too long id",
+			requestID: strings.Repeat("a", 129),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			if tt.requestID != "" {
+				req.Header.Set(requestid.Header, tt.requestID)
+			}
+
+			var seen string
+			handler := requestid.New()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = r.Header.Get(requestid.Header)
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.NotEmpty(t, seen)
+			require.Equal(t, seen, rr.Header().Get(requestid.Header))
+			if tt.kept {
+				require.Equal(t, tt.requestID, seen)
+			} else {
+				require.Len(t, seen, 32)
+			}
+		})
+	}
+}
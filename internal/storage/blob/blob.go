@@ -0,0 +1,13 @@
+package blob
+
+import "io"
+
+// Store keeps binary objects, such as uploaded images, under slash separated keys
+type Store interface {
+	// Put creates or replaces object stored under key
+	Put(key, contentType string, data io.Reader) error
+	// DeleteAll removes all objects with keys starting with given prefix
+	DeleteAll(prefix string) error
+	// URL returns address the object can be fetched from by clients
+	URL(key string) string
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"strings"
+)
+
+// Snapshots are JSON objects of movie and actor rows with their links by entity type,
+// changes recorded in audit log are diffs of them.
+var auditSnapshots = map[string]string{
+	entity.AuditMovie: `SELECT to_jsonb(m) || jsonb_build_object('actor_ids',
+				ARRAY(SELECT ma.actor_id FROM movie_actors ma WHERE ma.movie_id = m.id ORDER BY ma.actor_id))
+				FROM movies m WHERE m.id = $1`,
+	entity.AuditActor: `SELECT to_jsonb(a) || jsonb_build_object('movie_ids',
+				ARRAY(SELECT ma.movie_id FROM movie_actors ma WHERE ma.actor_id = a.id ORDER BY ma.movie_id))
+				FROM actors a WHERE a.id = $1`,
+}
+
+// snapshot returns JSON object of entity with given type and id, or nil if there is no such entity.
+func snapshot(tx *sql.Tx, entityType string, id int) ([]byte, error) {
+	var object []byte
+	err := tx.QueryRow(auditSnapshots[entityType], id).Scan(&object)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// recordChange records change of entity in audit log and saves its new revision within transaction making the change,
+// before is snapshot of the entity taken prior to change, nil if it didn't exist.
+func recordChange(tx *sql.Tx, audit entity.AuditInfo, action, entityType string, id int, before []byte) error {
+	after, err := snapshot(tx, entityType, id)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diffFields(before, after)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO audit_log (username, action, entity_type, entity_id, changes, request_id)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+		audit.Username, action, entityType, id, string(encoded),
+		sql.NullString{String: audit.RequestID, Valid: audit.RequestID != ""})
+	if err != nil {
+		return err
+	}
+
+	// Entity that is gone for good, or wasn't changed at all, keeps its latest revision
+	if after == nil || len(changes) == 0 {
+		return nil
+	}
+	return writeRevision(tx, audit, entityType, id, after)
+}
+
+// diffFields compares top-level fields of JSON objects and returns the ones with different values, nil object has no fields.
+func diffFields(before, after []byte) (map[string]entity.FieldChange, error) {
+	var beforeFields, afterFields map[string]json.RawMessage
+	if before != nil {
+		if err := json.Unmarshal(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(map[string]entity.FieldChange)
+	for field, value := range beforeFields {
+		if !bytes.Equal(value, afterFields[field]) {
+			changes[field] = entity.FieldChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = entity.FieldChange{After: value}
+		}
+	}
+
+	return changes, nil
+}
+
+// GetAuditLog returns audit entries matching filter, most recent first.
+func (s *Storage) GetAuditLog(filter *entity.AuditFilter, limit, offset int) ([]entity.AuditEntry, error) {
+	const op = "storage.postgres.GetAuditLog"
+
+	if filter == nil {
+		filter = &entity.AuditFilter{}
+	}
+
+	args := []any{limit, offset}
+	var conditions []string
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Username != "" {
+		add("username = $%d", filter.Username)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.EntityType != "" {
+		add("entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityID > 0 {
+		add("entity_id = $%d", filter.EntityID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	stmt, err := s.db.Prepare(
+		`SELECT id, username, action, entity_type, entity_id, changes, COALESCE(request_id, ''), created_at
+				FROM audit_log
+				` + where + `
+				ORDER BY created_at DESC, id DESC
+				LIMIT $1 OFFSET $2`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := []entity.AuditEntry{}
+	for rows.Next() {
+		var (
+			entry   entity.AuditEntry
+			changes []byte
+		)
+		err = rows.Scan(&entry.ID, &entry.Username, &entry.Action, &entry.EntityType, &entry.EntityID,
+			&changes, &entry.RequestID, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"encoding/json"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		changes map[string]entity.FieldChange
+	}{
+		{
+			name:  "Create",
+			after: `{"id": 1, "title": "Heat"}`,
+			changes: map[string]entity.FieldChange{
+				"id":    {After: json.RawMessage(`1`)},
+				"title": {After: json.RawMessage(`"Heat"`)},
+			},
+		},
+		{
+			name:   "Delete",
+			before: `{"id": 1}`,
+			changes: map[string]entity.FieldChange{
+				"id": {Before: json.RawMessage(`1`)},
+			},
+		},
+		{
+			name:   "Update changed fields only",
+			before: `{"id": 1, "rating": 7, "actor_ids": [1, 2], "deleted_at": null}`,
+			after:  `{"id": 1, "rating": 8, "actor_ids": [1, 2], "deleted_at": "2024-01-01T00:00:00+00:00"}`,
+			changes: map[string]entity.FieldChange{
+				"rating":     {Before: json.RawMessage(`7`), After: json.RawMessage(`8`)},
+				"deleted_at": {Before: json.RawMessage(`null`), After: json.RawMessage(`"2024-01-01T00:00:00+00:00"`)},
+			},
+		},
+		{
+			name:    "No changes",
+			before:  `{"id": 1, "aliases": []}`,
+			after:   `{"id": 1, "aliases": []}`,
+			changes: map[string]entity.FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var before, after []byte
+			if tt.before != "" {
+				before = []byte(tt.before)
+			}
+			if tt.after != "" {
+				after = []byte(tt.after)
+			}
+
+			changes, err := diffFields(before, after)
+			require.NoError(t, err)
+			require.Equal(t, tt.changes, changes)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"strings"
+)
+
+// GetAwards returns all awards with their categories and ceremonies ordered by name and year.
+func (s *Storage) GetAwards() ([]entity.Award, error) {
+	const op = "storage.postgres.GetAwards"
+
+	rows, err := s.db.Query("SELECT id, name FROM awards ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	awards := []entity.Award{}
+	index := make(map[int]int)
+	for rows.Next() {
+		award := entity.Award{Categories: []entity.AwardCategory{}, Ceremonies: []entity.Ceremony{}}
+		if err := rows.Scan(&award.ID, &award.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[award.ID] = len(awards)
+		awards = append(awards, award)
+	}
+
+	rows, err = s.db.Query("SELECT award_id, id, name FROM award_categories ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			awardID  int
+			category entity.AwardCategory
+		)
+		if err := rows.Scan(&awardID, &category.ID, &category.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		award := &awards[index[awardID]]
+		award.Categories = append(award.Categories, category)
+	}
+
+	rows, err = s.db.Query("SELECT award_id, id, year, held_on FROM award_ceremonies ORDER BY year")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			awardID  int
+			ceremony entity.Ceremony
+		)
+		if err := rows.Scan(&awardID, &ceremony.ID, &ceremony.Year, &ceremony.HeldOn); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		award := &awards[index[awardID]]
+		award.Ceremonies = append(award.Ceremonies, ceremony)
+	}
+
+	return awards, nil
+}
+
+func (s *Storage) CreateAward(award *entity.NewAward) (int, error) {
+	const op = "storage.postgres.CreateAward"
+
+	stmt, err := s.db.Prepare("INSERT INTO awards (name) VALUES ($1) RETURNING id")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(award.Name).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return 0, storage.ErrAwardExists
+		}
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+// DeleteAward deletes award with its categories, ceremonies and nominations.
+func (s *Storage) DeleteAward(id int) error {
+	const op = "storage.postgres.DeleteAward"
+
+	stmt, err := s.db.Prepare("DELETE FROM awards WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrAwardNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateAwardCategory(awardID int, category *entity.NewAwardCategory) (int, error) {
+	const op = "storage.postgres.CreateAwardCategory"
+
+	stmt, err := s.db.Prepare("INSERT INTO award_categories (award_id, name) VALUES ($1, $2) RETURNING id")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(awardID, category.Name).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case foreignKeyViolation:
+				return 0, storage.ErrAwardNotFound
+			case uniqueViolation:
+				return 0, storage.ErrAwardCategoryExists
+			}
+		}
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+func (s *Storage) CreateCeremony(awardID int, ceremony *entity.NewCeremony) (int, error) {
+	const op = "storage.postgres.CreateCeremony"
+
+	stmt, err := s.db.Prepare("INSERT INTO award_ceremonies (award_id, year, held_on) VALUES ($1, $2, $3) RETURNING id")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(awardID, ceremony.Year, ceremony.HeldOn).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case foreignKeyViolation:
+				return 0, storage.ErrAwardNotFound
+			case uniqueViolation:
+				return 0, storage.ErrCeremonyExists
+			}
+		}
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+// GetNominations returns nominations matching filter, latest ceremonies first.
+func (s *Storage) GetNominations(filter *entity.NominationFilter, limit, offset int) ([]entity.Nomination, error) {
+	const op = "storage.postgres.GetNominations"
+
+	if filter == nil {
+		filter = &entity.NominationFilter{}
+	}
+
+	args := []any{limit, offset}
+	// Nominations of deleted movies and actors are hidden until they are restored
+	conditions := []string{"m.deleted_at IS NULL", "a.deleted_at IS NULL"}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.AwardID > 0 {
+		add("aw.id = $%d", filter.AwardID)
+	}
+	if filter.CeremonyID > 0 {
+		add("n.ceremony_id = $%d", filter.CeremonyID)
+	}
+	if filter.CategoryID > 0 {
+		add("n.category_id = $%d", filter.CategoryID)
+	}
+	if filter.MovieID > 0 {
+		add("n.movie_id = $%d", filter.MovieID)
+	}
+	if filter.ActorID > 0 {
+		add("n.actor_id = $%d", filter.ActorID)
+	}
+	if filter.Won {
+		conditions = append(conditions, "n.won")
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	stmt, err := s.db.Prepare(
+		`SELECT n.id, aw.name, c.year, cat.name, m.id, m.title, a.id, a.name, n.won
+				FROM nominations n
+				JOIN award_ceremonies c ON c.id = n.ceremony_id
+				JOIN award_categories cat ON cat.id = n.category_id
+				JOIN awards aw ON aw.id = c.award_id
+				LEFT JOIN movies m ON m.id = n.movie_id
+				LEFT JOIN actors a ON a.id = n.actor_id
+				` + where + `
+				ORDER BY c.year DESC, aw.name, cat.name, n.won DESC, n.id
+				LIMIT $1 OFFSET $2`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var nominations []entity.Nomination
+	for rows.Next() {
+		var (
+			nomination            entity.Nomination
+			movieID, actorID      *int32
+			movieTitle, actorName *string
+		)
+		err = rows.Scan(&nomination.ID, &nomination.Award, &nomination.Year, &nomination.Category,
+			&movieID, &movieTitle, &actorID, &actorName, &nomination.Won)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if movieID != nil {
+			nomination.Movie = &entity.MovieSummary{ID: *movieID, Title: *movieTitle}
+		}
+		if actorID != nil {
+			nomination.Actor = &entity.ActorSummary{ID: *actorID, Name: *actorName}
+		}
+		nominations = append(nominations, nomination)
+	}
+
+	return nominations, nil
+}
+
+// CreateNomination creates nomination in category of the same award as ceremony.
+func (s *Storage) CreateNomination(nomination *entity.NewNomination) (int, error) {
+	const op = "storage.postgres.CreateNomination"
+
+	stmt, err := s.db.Prepare(
+		`INSERT INTO nominations (ceremony_id, category_id, movie_id, actor_id, won)
+				SELECT c.id, cat.id, $3::INT, $4::INT, $5::BOOLEAN
+				FROM award_ceremonies c
+				JOIN award_categories cat ON cat.award_id = c.award_id
+				WHERE c.id = $1 AND cat.id = $2
+				RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(
+		nomination.CeremonyID,
+		nomination.CategoryID,
+		sql.NullInt32{Int32: int32(nomination.MovieID), Valid: nomination.MovieID != 0},
+		sql.NullInt32{Int32: int32(nomination.ActorID), Valid: nomination.ActorID != 0},
+		nomination.Won,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrAwardCategoryNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			if pqErr.Constraint == "nominations_actor_id_fkey" {
+				return 0, storage.ErrActorNotFound
+			}
+			return 0, storage.ErrMovieNotFound
+		}
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+func (s *Storage) DeleteNomination(id int) error {
+	const op = "storage.postgres.DeleteNomination"
+
+	stmt, err := s.db.Prepare("DELETE FROM nominations WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrNominationNotFound
+	}
+
+	return nil
+}
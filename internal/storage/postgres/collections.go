@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+)
+
+// collectionQuery selects collections with ids of movies that are not deleted
+var collectionQuery = `SELECT c.id, c.owner, c.title, COALESCE(c.description, ''), c.public,
+		ARRAY(SELECT cm.movie_id FROM collection_movies cm WHERE cm.collection_id = c.id AND ` + liveMovie("cm.movie_id") + `
+			ORDER BY cm.position)
+		FROM collections c`
+
+func scanCollection(row rowScanner) (*entity.Collection, error) {
+	var collection entity.Collection
+	err := row.Scan(&collection.ID, &collection.Owner, &collection.Title, &collection.Description, &collection.Public,
+		(*pq.Int32Array)(&collection.MovieIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// GetCollections returns public collections and private collections of given user.
+func (s *Storage) GetCollections(username string, limit, offset int) ([]entity.Collection, error) {
+	const op = "storage.postgres.GetCollections"
+
+	stmt, err := s.db.Prepare(collectionQuery + " WHERE c.public OR c.owner = $1 ORDER BY c.id LIMIT $2 OFFSET $3")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var collections []entity.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		collections = append(collections, *collection)
+	}
+
+	return collections, nil
+}
+
+// GetCollectionById returns collection with its movies embedded in collection order.
+func (s *Storage) GetCollectionById(id int) (*entity.Collection, error) {
+	const op = "storage.postgres.GetCollectionById"
+
+	stmt, err := s.db.Prepare(collectionQuery + " WHERE c.id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	collection, err := scanCollection(stmt.QueryRow(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err = s.db.Prepare(
+		`SELECT ` + movieColumns + ` FROM collection_movies cm
+				JOIN movies m ON m.id = cm.movie_id
+				WHERE cm.collection_id = $1 AND m.deleted_at IS NULL
+				ORDER BY cm.position`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	collection.Movies = []entity.Movie{}
+	for rows.Next() {
+		var movie entity.Movie
+		if err := scanMovie(rows, &movie); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		collection.Movies = append(collection.Movies, movie)
+	}
+
+	return collection, nil
+}
+
+func (s *Storage) CreateCollection(owner string, collection *entity.NewCollection) (int, error) {
+	const op = "storage.postgres.CreateCollection"
+
+	stmt, err := s.db.Prepare("INSERT INTO collections (title, description, owner, public) VALUES ($1, $2, $3, $4) RETURNING id")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(collection.Title, collection.Description, owner, collection.Public).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateCollection(id int, collection *entity.NewCollection) error {
+	const op = "storage.postgres.UpdateCollection"
+
+	stmt, err := s.db.Prepare("UPDATE collections SET title = $1, description = $2, public = $3 WHERE id = $4")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.Exec(collection.Title, collection.Description, collection.Public, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCollection(id int) error {
+	const op = "storage.postgres.DeleteCollection"
+
+	stmt, err := s.db.Prepare("DELETE FROM collections WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetCollectionMovies replaces collection entries with given movies in given order, all of them must be live.
+func (s *Storage) SetCollectionMovies(id int, movieIDs []int32) error {
+	const op = "storage.postgres.SetCollectionMovies"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// Entries of deleted movies are not listed, so they are kept in case the movies are restored
+	stmt, err := tx.Prepare("DELETE FROM collection_movies cm WHERE cm.collection_id = $1 AND " + liveMovie("cm.movie_id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err = tx.Prepare(
+		`INSERT INTO collection_movies (collection_id, movie_id, position)
+				SELECT $1, id, $3 FROM movies WHERE id = $2 AND deleted_at IS NULL`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for position, movieID := range movieIDs {
+		res, err := stmt.Exec(id, movieID, position)
+		if err != nil {
+			if err := collectionMovieError(err); err != nil {
+				return err
+			}
+			return fmt.Errorf("%s: %w", op, constraintError(err))
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if affected == 0 {
+			return storage.ErrMovieNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddCollectionMovie appends live movie to the end of collection.
+func (s *Storage) AddCollectionMovie(id, movieID int) error {
+	const op = "storage.postgres.AddCollectionMovie"
+
+	stmt, err := s.db.Prepare(
+		`INSERT INTO collection_movies (collection_id, movie_id, position)
+				SELECT $1, m.id, COALESCE((SELECT MAX(position) + 1 FROM collection_movies WHERE collection_id = $1), 0)
+				FROM movies m WHERE m.id = $2 AND m.deleted_at IS NULL`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id, movieID)
+	if err != nil {
+		if err := collectionMovieError(err); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrMovieNotFound
+	}
+
+	return nil
+}
+
+// collectionMovieError translates violations of collection_movies constraints into storage errors, nil if err is not one
+func collectionMovieError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch {
+	case pqErr.Code == uniqueViolation:
+		return storage.ErrCollectionEntryExists
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "collection_movies_collection_id_fkey":
+		return storage.ErrCollectionNotFound
+	case pqErr.Code == foreignKeyViolation:
+		return storage.ErrMovieNotFound
+	}
+	return nil
+}
+
+func (s *Storage) RemoveCollectionMovie(id, movieID int) error {
+	const op = "storage.postgres.RemoveCollectionMovie"
+
+	stmt, err := s.db.Prepare("DELETE FROM collection_movies WHERE collection_id = $1 AND movie_id = $2")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrCollectionEntryNotFound
+	}
+
+	return nil
+}
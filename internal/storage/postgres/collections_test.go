@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAddCollectionMovie(t *testing.T) {
+	tests := []struct {
+		name  string
+		query fakeQuery
+		err   error
+	}{
+		{
+			name:  "Success",
+			query: fakeQuery{match: "INSERT INTO collection_movies", args: []driver.Value{int64(1), int64(2)}, affected: 1},
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "INSERT INTO collection_movies"},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Movie already in collection",
+			query: fakeQuery{match: "INSERT INTO collection_movies", err: &pq.Error{Code: uniqueViolation, Constraint: "collection_movies_pkey"}},
+			err:   storage.ErrCollectionEntryExists,
+		},
+		{
+			name:  "Missing collection",
+			query: fakeQuery{match: "INSERT INTO collection_movies", err: &pq.Error{Code: foreignKeyViolation, Constraint: "collection_movies_collection_id_fkey"}},
+			err:   storage.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			err := s.AddCollectionMovie(1, 2)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetCollectionMovies(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []fakeQuery
+		err     error
+		// committed tells if movies are expected to be set
+		committed bool
+	}{
+		{
+			name: "Success",
+			queries: []fakeQuery{
+				{match: "DELETE FROM collection_movies", args: []driver.Value{int64(1)}},
+				{match: "INSERT INTO collection_movies", args: []driver.Value{int64(1), int64(3), int64(0)}, affected: 1},
+				{match: "INSERT INTO collection_movies", args: []driver.Value{int64(1), int64(2), int64(1)}, affected: 1},
+			},
+			committed: true,
+		},
+		{
+			name: "Missing or deleted movie",
+			queries: []fakeQuery{
+				{match: "DELETE FROM collection_movies"},
+				{match: "INSERT INTO collection_movies", affected: 1},
+				{match: "INSERT INTO collection_movies"},
+			},
+			err: storage.ErrMovieNotFound,
+		},
+		{
+			name: "Missing collection",
+			queries: []fakeQuery{
+				{match: "DELETE FROM collection_movies"},
+				{match: "INSERT INTO collection_movies", err: &pq.Error{Code: foreignKeyViolation, Constraint: "collection_movies_collection_id_fkey"}},
+			},
+			err: storage.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, db := newFakeStorage(t, tt.queries...)
+
+			err := s.SetCollectionMovies(1, []int32{3, 2})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.committed, db.committed)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/storage"
+	"regexp"
+)
+
+// constraintFields maps constraints of movies and actors to JSON paths of fields they are on
+var constraintFields = map[string]string{
+	"rating_check":               "rating",
+	"runtime_check":              "runtime",
+	"budget_check":               "budget.amount",
+	"box_office_check":           "box_office.amount",
+	"death_date_check":           "deathdate",
+	"movie_actors_actor_id_fkey": "actor_ids",
+	"movie_actors_pkey":          "actor_ids",
+}
+
+// columnFields maps NOT NULL columns of movies and actors to JSON paths of fields they are stored from
+var columnFields = map[string]string{
+	"title":        "title",
+	"release_date": "release_date",
+	"rating":       "rating",
+	"name":         "name",
+	"birth_date":   "birthdate",
+}
+
+// keyValue extracts value from error detail like `Key (actor_id)=(5) is not present in table "actors".`
+var keyValue = regexp.MustCompile(`^Key \([^)]*\)=\((.*)\) `)
+
+// constraintError translates integrity constraint violation into storage.ConstraintError, other errors are returned as is
+func constraintError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	var sentinel error
+	switch pqErr.Code {
+	case foreignKeyViolation:
+		sentinel = storage.ErrReferenceNotFound
+	case uniqueViolation:
+		sentinel = storage.ErrDuplicate
+	case notNullViolation:
+		sentinel = storage.ErrValueRequired
+	case checkViolation:
+		sentinel = storage.ErrConstraintViolated
+	default:
+		return err
+	}
+
+	field, ok := constraintFields[pqErr.Constraint]
+	if !ok {
+		field = columnFields[pqErr.Column]
+	}
+
+	var value string
+	if match := keyValue.FindStringSubmatch(pqErr.Detail); match != nil {
+		value = match[1]
+	}
+
+	return &storage.ConstraintError{
+		Err:        sentinel,
+		Constraint: pqErr.Constraint,
+		Field:      field,
+		Value:      value,
+	}
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestConstraintError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		// want is nil when error is expected to be returned as is
+		want *storage.ConstraintError
+	}{
+		{
+			name: "Missing actor",
+			err: &pq.Error{
+				Code:       foreignKeyViolation,
+				Constraint: "movie_actors_actor_id_fkey",
+				Detail:     `Key (actor_id)=(5) is not present in table "actors".`,
+			},
+			want: &storage.ConstraintError{
+				Err:        storage.ErrReferenceNotFound,
+				Constraint: "movie_actors_actor_id_fkey",
+				Field:      "actor_ids",
+				Value:      "5",
+			},
+		},
+		{
+			name: "Duplicate actor",
+			err: &pq.Error{
+				Code:       uniqueViolation,
+				Constraint: "movie_actors_pkey",
+				Detail:     `Key (movie_id, actor_id)=(1, 5) already exists.`,
+			},
+			want: &storage.ConstraintError{
+				Err:        storage.ErrDuplicate,
+				Constraint: "movie_actors_pkey",
+				Field:      "actor_ids",
+				Value:      "1, 5",
+			},
+		},
+		{
+			name: "Rating out of range",
+			err:  &pq.Error{Code: checkViolation, Constraint: "rating_check"},
+			want: &storage.ConstraintError{
+				Err:        storage.ErrConstraintViolated,
+				Constraint: "rating_check",
+				Field:      "rating",
+			},
+		},
+		{
+			name: "Missing birth date",
+			err:  &pq.Error{Code: notNullViolation, Column: "birth_date"},
+			want: &storage.ConstraintError{
+				Err:   storage.ErrValueRequired,
+				Field: "birthdate",
+			},
+		},
+		{
+			name: "Unknown constraint",
+			err:  &pq.Error{Code: checkViolation, Constraint: "other_check"},
+			want: &storage.ConstraintError{
+				Err:        storage.ErrConstraintViolated,
+				Constraint: "other_check",
+			},
+		},
+		{
+			name: "Other database error",
+			err:  &pq.Error{Code: "40001"},
+		},
+		{
+			name: "Not a database error",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := constraintError(fmt.Errorf("exec: %w", tt.err))
+			if tt.want == nil {
+				require.ErrorIs(t, err, tt.err)
+				require.NotErrorIs(t, err, storage.ErrConstraintViolated)
+				return
+			}
+
+			var constraintErr *storage.ConstraintError
+			require.ErrorAs(t, err, &constraintErr)
+			require.Equal(t, tt.want, constraintErr)
+			require.ErrorIs(t, err, tt.want.Err)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"slices"
+)
+
+func (s *Storage) actorExists(id int) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM actors WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	return exists, err
+}
+
+// GetCostars returns actors who played together with given actor, ranked by number of shared movies.
+func (s *Storage) GetCostars(actorID, limit, offset int) ([]entity.Costar, error) {
+	const op = "storage.postgres.GetCostars"
+
+	exists, err := s.actorExists(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, storage.ErrActorNotFound
+	}
+
+	stmt, err := s.db.Prepare(
+		`SELECT a.id, a.name, count(*), array_agg(other.movie_id ORDER BY other.movie_id)
+				FROM movie_actors own
+				JOIN movie_actors other ON other.movie_id = own.movie_id AND other.actor_id <> own.actor_id
+				JOIN actors a ON a.id = other.actor_id AND a.deleted_at IS NULL
+				WHERE own.actor_id = $1 AND ` + liveMovie("own.movie_id") + `
+				GROUP BY a.id
+				ORDER BY count(*) DESC, a.id
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(actorID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var costars []entity.Costar
+	for rows.Next() {
+		var costar entity.Costar
+		err = rows.Scan(&costar.ID, &costar.Name, &costar.SharedMovies, (*pq.Int32Array)(&costar.MovieIDs))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		costars = append(costars, costar)
+	}
+
+	return costars, nil
+}
+
+// costarLink is an edge of co-star graph, actors `from` and `to` both played in `movie`
+type costarLink struct {
+	from, movie, to int32
+}
+
+// getCostarLinks returns all links from given actors, ordered so traversal is deterministic
+func (s *Storage) getCostarLinks(actorIDs []int32) ([]costarLink, error) {
+	rows, err := s.db.Query(
+		`SELECT own.actor_id, own.movie_id, other.actor_id
+				FROM movie_actors own
+				JOIN movie_actors other ON other.movie_id = own.movie_id AND other.actor_id <> own.actor_id
+				JOIN movies m ON m.id = own.movie_id AND m.deleted_at IS NULL
+				JOIN actors a ON a.id = other.actor_id AND a.deleted_at IS NULL
+				WHERE own.actor_id = ANY($1)
+				ORDER BY own.actor_id, own.movie_id, other.actor_id`,
+		pq.Array(actorIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []costarLink
+	for rows.Next() {
+		var link costarLink
+		if err := rows.Scan(&link.from, &link.movie, &link.to); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// pathNode tells how traversal reached an actor
+type pathNode struct {
+	parent, movie int32
+	depth         int
+}
+
+// FindActorPath returns shortest chain of co-stars between two actors, at most maxDepth movies long.
+func (s *Storage) FindActorPath(fromID, toID, maxDepth int) (*entity.ActorPath, error) {
+	const op = "storage.postgres.FindActorPath"
+
+	for _, id := range []int{fromID, toID} {
+		exists, err := s.actorExists(id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return nil, storage.ErrActorNotFound
+		}
+	}
+
+	actorIDs, movieIDs, err := shortestCostarPath(int32(fromID), int32(toID), maxDepth, s.getCostarLinks)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if actorIDs == nil {
+		return nil, storage.ErrPathNotFound
+	}
+
+	path, err := s.describeActorPath(actorIDs, movieIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return path, nil
+}
+
+// shortestCostarPath finds shortest chain of actors and linking movies between two actors, nil chain means there is none.
+// Search runs breadth-first from both actors at once, expanding the smaller frontier level by level,
+// so only a small part of the graph around both ends is loaded.
+func shortestCostarPath(from, to int32, maxDepth int, getLinks func(actorIDs []int32) ([]costarLink, error)) ([]int32, []int32, error) {
+	forward := map[int32]pathNode{from: {}}
+	backward := map[int32]pathNode{to: {}}
+	forwardFrontier, backwardFrontier := []int32{from}, []int32{to}
+	forwardDepth, backwardDepth := 0, 0
+
+	meet, found := from, from == to
+	for !found && forwardDepth+backwardDepth < maxDepth {
+		expandForward := len(forwardFrontier) <= len(backwardFrontier)
+		frontier, visited, other, depth := backwardFrontier, backward, forward, backwardDepth+1
+		if expandForward {
+			frontier, visited, other, depth = forwardFrontier, forward, backward, forwardDepth+1
+		}
+
+		links, err := getLinks(frontier)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Whole level is expanded before checking for a meeting point,
+		// so the shortest of all chains passing through this level is picked
+		var next []int32
+		bestLength := 0
+		for _, link := range links {
+			if _, seen := visited[link.to]; seen {
+				continue
+			}
+			visited[link.to] = pathNode{parent: link.from, movie: link.movie, depth: depth}
+			next = append(next, link.to)
+
+			if node, ok := other[link.to]; ok && (!found || depth+node.depth < bestLength) {
+				meet, found, bestLength = link.to, true, depth+node.depth
+			}
+		}
+
+		if expandForward {
+			forwardFrontier, forwardDepth = next, depth
+		} else {
+			backwardFrontier, backwardDepth = next, depth
+		}
+		if len(next) == 0 {
+			break
+		}
+	}
+
+	if !found {
+		return nil, nil, nil
+	}
+
+	// Walk from meeting point back to the first actor, then forward to the second one
+	var actorIDs, movieIDs []int32
+	for id := meet; id != from; id = forward[id].parent {
+		actorIDs = append(actorIDs, id)
+		movieIDs = append(movieIDs, forward[id].movie)
+	}
+	actorIDs = append(actorIDs, from)
+	slices.Reverse(actorIDs)
+	slices.Reverse(movieIDs)
+	for id := meet; id != to; id = backward[id].parent {
+		actorIDs = append(actorIDs, backward[id].parent)
+		movieIDs = append(movieIDs, backward[id].movie)
+	}
+
+	return actorIDs, movieIDs, nil
+}
+
+// describeActorPath fills names and titles of actors and movies on the path
+func (s *Storage) describeActorPath(actorIDs, movieIDs []int32) (*entity.ActorPath, error) {
+	names := make(map[int32]string, len(actorIDs))
+	rows, err := s.db.Query("SELECT id, name FROM actors WHERE id = ANY($1)", pq.Array(actorIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int32
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	titles := make(map[int32]string, len(movieIDs))
+	rows, err = s.db.Query("SELECT id, title FROM movies WHERE id = ANY($1)", pq.Array(movieIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int32
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, err
+		}
+		titles[id] = title
+	}
+
+	path := entity.ActorPath{
+		Degrees: len(movieIDs),
+		Actors:  make([]entity.ActorSummary, len(actorIDs)),
+		Movies:  make([]entity.MovieSummary, len(movieIDs)),
+	}
+	for i, id := range actorIDs {
+		path.Actors[i] = entity.ActorSummary{ID: id, Name: names[id]}
+	}
+	for i, id := range movieIDs {
+		path.Movies[i] = entity.MovieSummary{ID: id, Title: titles[id]}
+	}
+
+	return &path, nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// graphLinks returns link getter over movie casts given as movie id to actor ids
+func graphLinks(casts map[int32][]int32, calls *int) func([]int32) ([]costarLink, error) {
+	return func(actorIDs []int32) ([]costarLink, error) {
+		*calls++
+		var links []costarLink
+		for _, from := range actorIDs {
+			for movie := int32(1); movie <= int32(len(casts)); movie++ {
+				cast := casts[movie]
+				for _, actor := range cast {
+					if actor != from {
+						continue
+					}
+					for _, to := range cast {
+						if to != from {
+							links = append(links, costarLink{from: from, movie: movie, to: to})
+						}
+					}
+				}
+			}
+		}
+		return links, nil
+	}
+}
+
+func TestShortestCostarPath(t *testing.T) {
+	// 1 - 2 - 3 - 4 - 5 chain through movies 1..4, with shortcut 1 - 6 - 5 through movies 5 and 6
+	casts := map[int32][]int32{
+		1: {1, 2},
+		2: {2, 3},
+		3: {3, 4},
+		4: {4, 5},
+		5: {1, 6},
+		6: {6, 5},
+		7: {7, 8},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int32
+		maxDepth int
+		actorIDs []int32
+		movieIDs []int32
+	}{
+		{
+			name:     "Same actor",
+			from:     1,
+			to:       1,
+			maxDepth: 6,
+			actorIDs: []int32{1},
+		},
+		{
+			name:     "Direct costars",
+			from:     2,
+			to:       3,
+			maxDepth: 6,
+			actorIDs: []int32{2, 3},
+			movieIDs: []int32{2},
+		},
+		{
+			name:     "Shortcut is preferred",
+			from:     1,
+			to:       5,
+			maxDepth: 6,
+			actorIDs: []int32{1, 6, 5},
+			movieIDs: []int32{5, 6},
+		},
+		{
+			name:     "Longer path",
+			from:     2,
+			to:       4,
+			maxDepth: 6,
+			actorIDs: []int32{2, 3, 4},
+			movieIDs: []int32{2, 3},
+		},
+		{
+			name:     "Depth limit",
+			from:     2,
+			to:       5,
+			maxDepth: 2,
+		},
+		{
+			name:     "Disconnected",
+			from:     1,
+			to:       8,
+			maxDepth: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			actorIDs, movieIDs, err := shortestCostarPath(tt.from, tt.to, tt.maxDepth, graphLinks(casts, &calls))
+			require.NoError(t, err)
+			require.Equal(t, tt.actorIDs, actorIDs)
+			require.Equal(t, tt.movieIDs, movieIDs)
+			require.LessOrEqual(t, calls, tt.maxDepth)
+		})
+	}
+}
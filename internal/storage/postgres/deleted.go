@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"time"
+)
+
+// RestoreMovie undoes deletion of movie, its cast and other links were kept while it was deleted.
+func (s *Storage) RestoreMovie(id int, audit entity.AuditInfo) error {
+	const op = "storage.postgres.RestoreMovie"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshot(tx, entity.AuditMovie, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec("UPDATE movies SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrMovieNotFound
+	}
+
+	if err := touchMovieActors(tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordChange(tx, audit, entity.AuditRestore, entity.AuditMovie, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RestoreActor undoes deletion of actor, their movies and other links were kept while they were deleted.
+func (s *Storage) RestoreActor(id int, audit entity.AuditInfo) error {
+	const op = "storage.postgres.RestoreActor"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshot(tx, entity.AuditActor, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec("UPDATE actors SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrActorNotFound
+	}
+
+	if err := touchActorMovies(tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordChange(tx, audit, entity.AuditRestore, entity.AuditActor, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes movies and actors deleted before given time together with their links,
+// it returns number of removed movies and actors.
+func (s *Storage) PurgeDeleted(deletedBefore time.Time) (int64, error) {
+	const op = "storage.postgres.PurgeDeleted"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var purged int64
+	for _, table := range []string{"movies", "actors"} {
+		res, err := tx.Exec("DELETE FROM "+table+" WHERE deleted_at < $1", deletedBefore)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		purged += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+)
+
+// GetDuplicateActors returns pairs of actors with similar names born on the same date and pairs
+// with equal names, having name similarity of at least minSimilarity. Most similar pairs come first.
+func (s *Storage) GetDuplicateActors(minSimilarity float64, limit, offset int) ([]entity.DuplicateCandidate, error) {
+	const op = "storage.postgres.GetDuplicateActors"
+
+	stmt, err := s.db.Prepare(
+		`SELECT a.id, a.name, b.id, b.name, similarity(lower(a.name), lower(b.name)) AS sim,
+				a.birth_date = b.birth_date
+				FROM actors a
+				JOIN actors b ON b.id > a.id AND (b.birth_date = a.birth_date OR lower(b.name) = lower(a.name))
+				WHERE similarity(lower(a.name), lower(b.name)) >= $1 AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+				ORDER BY sim DESC, a.id, b.id
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(minSimilarity, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var candidates []entity.DuplicateCandidate
+	for rows.Next() {
+		var candidate entity.DuplicateCandidate
+		err = rows.Scan(&candidate.Actor.ID, &candidate.Actor.Name, &candidate.Duplicate.ID, &candidate.Duplicate.Name,
+			&candidate.Similarity, &candidate.SameBirthDate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// MergeActors moves movies, crew credits, translations, tags and nominations of duplicate actor to actor with given id,
+// keeps name of duplicate as alias, deletes duplicate and redirects its id to the surviving actor.
+func (s *Storage) MergeActors(id, duplicateID int, audit entity.AuditInfo) error {
+	const op = "storage.postgres.MergeActors"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var locked int
+	err = tx.QueryRow(
+		`SELECT count(*) FROM (SELECT id FROM actors WHERE id IN ($1, $2) AND deleted_at IS NULL FOR UPDATE) a`, id, duplicateID).
+		Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if locked != 2 {
+		return storage.ErrActorNotFound
+	}
+
+	before, err := snapshot(tx, entity.AuditActor, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	duplicateBefore, err := snapshot(tx, entity.AuditActor, duplicateID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	queries := []string{
+		`INSERT INTO movie_actors (movie_id, actor_id)
+				SELECT movie_id, $1::INT FROM movie_actors WHERE actor_id = $2
+				ON CONFLICT DO NOTHING`,
+		`INSERT INTO movie_crew (movie_id, person_id, role)
+				SELECT movie_id, $1::INT, role FROM movie_crew WHERE person_id = $2
+				ON CONFLICT DO NOTHING`,
+		`INSERT INTO actor_translations (actor_id, lang, name)
+				SELECT $1::INT, lang, name FROM actor_translations WHERE actor_id = $2
+				ON CONFLICT DO NOTHING`,
+		`INSERT INTO actor_tags (actor_id, tag_id)
+				SELECT $1::INT, tag_id FROM actor_tags WHERE actor_id = $2
+				ON CONFLICT DO NOTHING`,
+		`UPDATE actors a SET
+				aliases = ARRAY(SELECT DISTINCT alias
+					FROM unnest(a.aliases || d.aliases || d.name::VARCHAR(255)) AS alias
+					WHERE alias <> a.name
+					ORDER BY alias),
+				headshot = COALESCE(a.headshot, d.headshot),
+				version = a.version + 1, updated_at = now()
+				FROM actors d
+				WHERE a.id = $1 AND d.id = $2`,
+		`UPDATE nominations SET actor_id = $1 WHERE actor_id = $2`,
+		`UPDATE actor_redirects SET actor_id = $1 WHERE actor_id = $2`,
+		`INSERT INTO actor_redirects (old_id, actor_id) VALUES ($2, $1)`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, id, duplicateID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM actors WHERE id = $1`, duplicateID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := touchActorMovies(tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Both actors are recorded as merged, the duplicate with all of its fields gone
+	if err := recordChange(tx, audit, entity.AuditMerge, entity.AuditActor, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := recordChange(tx, audit, entity.AuditMerge, entity.AuditActor, duplicateID, duplicateBefore); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetActorRedirect returns id of actor that merged actor with given id.
+func (s *Storage) GetActorRedirect(id int) (int, error) {
+	const op = "storage.postgres.GetActorRedirect"
+
+	stmt, err := s.db.Prepare("SELECT actor_id FROM actor_redirects WHERE old_id = $1")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var actorID int
+	err = stmt.QueryRow(id).Scan(&actorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrActorNotFound
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return actorID, nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeQuery is scripted result of statement containing match
+type fakeQuery struct {
+	match string
+	// args are expected arguments, they are not checked when nil
+	args    []driver.Value
+	columns []string
+	rows    [][]driver.Value
+	// affected is number of rows affected by statement executed with Exec
+	affected int64
+	err      error
+}
+
+// fakeDB replays scripted queries in order, like PostgreSQL it requires exactly as many arguments as there are placeholders
+type fakeDB struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	queries []fakeQuery
+	// committed and rolledBack tell how transaction ended
+	committed  bool
+	rolledBack bool
+}
+
+// newFakeStorage returns storage over fake database running given queries, all of them must be run by the test
+func newFakeStorage(t *testing.T, queries ...fakeQuery) (*Storage, *fakeDB) {
+	t.Helper()
+
+	db := &fakeDB{t: t, queries: queries}
+	sqlDB := sql.OpenDB(db)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		if len(db.queries) != 0 {
+			t.Errorf("queries were not run: %q", db.queries[0].match)
+		}
+	})
+
+	return &Storage{db: sqlx.NewDb(sqlDB, "postgres")}, db
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn{db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return fakeDriver{db}
+}
+
+// next returns scripted result of query run with args
+func (db *fakeDB) next(query string, args []driver.Value) (fakeQuery, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(db.queries) == 0 {
+		return fakeQuery{}, fmt.Errorf("unexpected query %q", query)
+	}
+	expected := db.queries[0]
+	if !strings.Contains(query, expected.match) {
+		return fakeQuery{}, fmt.Errorf("query %q doesn't contain %q", query, expected.match)
+	}
+	if expected.args != nil && fmt.Sprint(expected.args) != fmt.Sprint(args) {
+		return fakeQuery{}, fmt.Errorf("query %q got arguments %v, expected %v", expected.match, args, expected.args)
+	}
+	db.queries = db.queries[1:]
+
+	return expected, expected.err
+}
+
+type fakeDriver struct {
+	db *fakeDB
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn(d), nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx(c), nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.committed = true
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rolledBack = true
+	return nil
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+// NumInput is the highest placeholder number, database/sql rejects calls with other number of arguments
+func (s fakeStmt) NumInput() int {
+	n := 0
+	for _, match := range placeholder.FindAllStringSubmatch(s.query, -1) {
+		i, _ := strconv.Atoi(match[1])
+		n = max(n, i)
+	}
+	return n
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	q, err := s.db.next(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(q.affected), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	q, err := s.db.next(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: q.columns, rows: q.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if r.columns == nil && len(r.rows) > 0 {
+		return make([]string, len(r.rows[0]))
+	}
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+)
+
+// SetMoviePoster replaces poster of movie, nil image removes it.
+// It returns replaced poster, so its files can be removed once the new one is saved.
+func (s *Storage) SetMoviePoster(id int, image *entity.Image) (*entity.Image, error) {
+	const op = "storage.postgres.SetMoviePoster"
+
+	stmt, err := s.db.Prepare(
+		`UPDATE movies m SET poster = $1, version = m.version + 1, updated_at = now()
+				FROM (SELECT id, poster FROM movies WHERE id = $2 AND deleted_at IS NULL FOR UPDATE) old
+				WHERE m.id = old.id
+				RETURNING old.poster`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var previous *entity.Image
+	if err := stmt.QueryRow(image, id).Scan(&previous); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrMovieNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return previous, nil
+}
+
+// SetActorHeadshot replaces headshot of actor, nil image removes it.
+// It returns replaced headshot, so its files can be removed once the new one is saved.
+func (s *Storage) SetActorHeadshot(id int, image *entity.Image) (*entity.Image, error) {
+	const op = "storage.postgres.SetActorHeadshot"
+
+	stmt, err := s.db.Prepare(
+		`UPDATE actors a SET headshot = $1, version = a.version + 1, updated_at = now()
+				FROM (SELECT id, headshot FROM actors WHERE id = $2 AND deleted_at IS NULL FOR UPDATE) old
+				WHERE a.id = old.id
+				RETURNING old.headshot`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var previous *entity.Image
+	if err := stmt.QueryRow(image, id).Scan(&previous); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrActorNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return previous, nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetMoviePoster(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    fakeQuery
+		previous *entity.Image
+		err      error
+	}{
+		{
+			name:  "First poster",
+			query: fakeQuery{match: "RETURNING old.poster", rows: [][]driver.Value{{nil}}},
+		},
+		{
+			name: "Replaced poster",
+			query: fakeQuery{match: "RETURNING old.poster",
+				rows: [][]driver.Value{{[]byte(`{"url":"/images/movies/1/poster/v1/original","width":10,"height":15,"thumbnails":{},"prefix":"movies/1/poster/v1"}`)}}},
+			previous: &entity.Image{URL: "/images/movies/1/poster/v1/original", Width: 10, Height: 15, Thumbnails: map[int]string{},
+				Prefix: "movies/1/poster/v1"},
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "RETURNING old.poster"},
+			err:   storage.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			previous, err := s.SetMoviePoster(1, &entity.Image{Prefix: "movies/1/poster/v2"})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.previous, previous)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+)
+
+// EmbedActors sets actors of given movies, with names in the first available of given languages.
+// Actors of all movies are read with a single query, movies without live actors get empty list.
+func (s *Storage) EmbedActors(movies []entity.Movie, langs []string) error {
+	const op = "storage.postgres.EmbedActors"
+
+	var ids []int32
+	for _, movie := range movies {
+		ids = append(ids, movie.ActorIDs...)
+	}
+
+	actors, err := s.getActorsByIds(ids, langs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range movies {
+		embedded := make([]entity.Actor, 0, len(movies[i].ActorIDs))
+		for _, id := range movies[i].ActorIDs {
+			if actor, ok := actors[id]; ok {
+				embedded = append(embedded, actor)
+			}
+		}
+		movies[i].Actors = &embedded
+	}
+
+	return nil
+}
+
+// EmbedMovies sets movies of given actors, with titles and descriptions in the first available of given languages.
+// Movies of all actors are read with a single query, actors without live movies get empty list.
+func (s *Storage) EmbedMovies(actors []entity.Actor, langs []string) error {
+	const op = "storage.postgres.EmbedMovies"
+
+	var ids []int32
+	for _, actor := range actors {
+		ids = append(ids, actor.MovieIDs...)
+	}
+
+	movies, err := s.getMoviesByIds(ids, langs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range actors {
+		embedded := make([]entity.Movie, 0, len(actors[i].MovieIDs))
+		for _, id := range actors[i].MovieIDs {
+			if movie, ok := movies[id]; ok {
+				embedded = append(embedded, movie)
+			}
+		}
+		actors[i].Movies = &embedded
+	}
+
+	return nil
+}
+
+// getActorsByIds returns live actors with given ids by id, no query is made for no ids
+func (s *Storage) getActorsByIds(ids []int32, langs []string) (map[int32]entity.Actor, error) {
+	actors := make(map[int32]entity.Actor)
+	if len(ids) == 0 {
+		return actors, nil
+	}
+
+	stmt, err := s.db.Prepare(`SELECT ` + localizedActorColumns + ` FROM actors a ` + actorTranslationJoin(2) + `
+				WHERE a.id = ANY($1) AND a.deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(pq.Int32Array(ids), pq.Array(langs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var actor entity.Actor
+		if err := scanActor(rows, &actor); err != nil {
+			return nil, err
+		}
+		actors[int32(actor.ID)] = actor
+	}
+
+	return actors, rows.Err()
+}
+
+// getMoviesByIds returns live movies with given ids by id, no query is made for no ids
+func (s *Storage) getMoviesByIds(ids []int32, langs []string) (map[int32]entity.Movie, error) {
+	movies := make(map[int32]entity.Movie)
+	if len(ids) == 0 {
+		return movies, nil
+	}
+
+	stmt, err := s.db.Prepare(`SELECT ` + localizedMovieColumns + ` FROM movies m ` + movieTranslationJoin(2) + `
+				WHERE m.id = ANY($1) AND m.deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(pq.Int32Array(ids), pq.Array(langs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movie entity.Movie
+		if err := scanMovie(rows, &movie); err != nil {
+			return nil, err
+		}
+		movies[int32(movie.ID)] = movie
+	}
+
+	return movies, rows.Err()
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEmbedActors(t *testing.T) {
+	tests := []struct {
+		name    string
+		movies  []entity.Movie
+		queries []fakeQuery
+	}{
+		{
+			name:   "No actors",
+			movies: []entity.Movie{{ID: 1}, {ID: 2}},
+		},
+		{
+			name:    "Deleted actors",
+			movies:  []entity.Movie{{ID: 1, NewMovie: entity.NewMovie{ActorIDs: []int32{3}}}, {ID: 2}},
+			queries: []fakeQuery{{match: "FROM actors a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.queries...)
+
+			require.NoError(t, s.EmbedActors(tt.movies, []string{"en"}))
+			for _, movie := range tt.movies {
+				require.NotNil(t, movie.Actors)
+				require.Empty(t, *movie.Actors)
+			}
+		})
+	}
+}
+
+func TestEmbedMovies(t *testing.T) {
+	tests := []struct {
+		name    string
+		actors  []entity.Actor
+		queries []fakeQuery
+	}{
+		{
+			name:   "No movies",
+			actors: []entity.Actor{{ID: 1}, {ID: 2}},
+		},
+		{
+			name:    "Deleted movies",
+			actors:  []entity.Actor{{ID: 1, MovieIDs: []int32{3}}, {ID: 2}},
+			queries: []fakeQuery{{match: "FROM movies m"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.queries...)
+
+			require.NoError(t, s.EmbedMovies(tt.actors, []string{"en"}))
+			for _, actor := range tt.actors {
+				require.NotNil(t, actor.Movies)
+				require.Empty(t, *actor.Movies)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"time"
+)
+
+func (s *Storage) getMovieCrew(movieID int) ([]entity.CrewMember, error) {
+	const op = "storage.postgres.getMovieCrew"
+
+	stmt, err := s.db.Prepare(
+		`SELECT mc.person_id, mc.role FROM movie_crew mc
+				WHERE mc.movie_id = $1 AND ` + liveActor("mc.person_id") + `
+				ORDER BY mc.role, mc.person_id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var crew []entity.CrewMember
+	for rows.Next() {
+		var member entity.CrewMember
+		if err := rows.Scan(&member.PersonID, &member.Role); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		crew = append(crew, member)
+	}
+
+	return crew, nil
+}
+
+// AddCrewMember credits live person in role on live movie, each credit can be added once
+func (s *Storage) AddCrewMember(movieID int, member *entity.CrewMember) error {
+	const op = "storage.postgres.AddCrewMember"
+
+	stmt, err := s.db.Prepare(
+		`WITH movie AS (SELECT id FROM movies WHERE id = $1 AND deleted_at IS NULL),
+				person AS (SELECT id FROM actors WHERE id = $2 AND deleted_at IS NULL),
+				added AS (INSERT INTO movie_crew (movie_id, person_id, role)
+					SELECT movie.id, person.id, $3::crew_role FROM movie, person
+					ON CONFLICT DO NOTHING
+					RETURNING movie_id),
+				touched AS (UPDATE movies SET updated_at = now() WHERE id IN (SELECT movie_id FROM added))
+				SELECT EXISTS (SELECT 1 FROM movie), EXISTS (SELECT 1 FROM person), EXISTS (SELECT 1 FROM added)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var movieExists, personExists, added bool
+	err = stmt.QueryRow(movieID, member.PersonID, member.Role).Scan(&movieExists, &personExists, &added)
+	if err != nil {
+		// Movie or person was purged concurrently
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			if pqErr.Constraint == "movie_crew_movie_id_fkey" {
+				return storage.ErrMovieNotFound
+			}
+			return storage.ErrPersonNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	switch {
+	case !movieExists:
+		return storage.ErrMovieNotFound
+	case !personExists:
+		return storage.ErrPersonNotFound
+	case !added:
+		return storage.ErrCreditExists
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveCrewMember(movieID int, member *entity.CrewMember) error {
+	const op = "storage.postgres.RemoveCrewMember"
+
+	stmt, err := s.db.Prepare(touch("movies", "DELETE FROM movie_crew WHERE movie_id = $1 AND person_id = $2 AND role::TEXT = $3 RETURNING movie_id AS id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(movieID, member.PersonID, member.Role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrCreditNotFound
+	}
+
+	return nil
+}
+
+// personQuery selects people together with acting credits and crew credits in movies that are not deleted.
+// Crew credits are returned as two arrays of the same order, since pq can't scan composite types.
+var personQuery = `SELECT a.id, ` + newActorColumns + `,
+		ARRAY(SELECT ma.movie_id FROM movie_actors ma WHERE ma.actor_id = a.id AND ` + liveMovie("ma.movie_id") + `
+			ORDER BY ma.movie_id),
+		ARRAY(SELECT mc.movie_id FROM movie_crew mc WHERE mc.person_id = a.id AND ` + liveMovie("mc.movie_id") + `
+			ORDER BY mc.movie_id, mc.role),
+		ARRAY(SELECT mc.role::TEXT FROM movie_crew mc WHERE mc.person_id = a.id AND ` + liveMovie("mc.movie_id") + `
+			ORDER BY mc.movie_id, mc.role)
+		FROM actors a`
+
+func scanPerson(row rowScanner) (*entity.Person, error) {
+	var (
+		person         entity.Person
+		creditMovieIDs []int32
+		creditRoles    []string
+	)
+	dest := append([]any{&person.ID}, newActorDest(&person.NewActor)...)
+	err := row.Scan(append(dest,
+		(*pq.Int32Array)(&person.MovieIDs), (*pq.Int32Array)(&creditMovieIDs), (*pq.StringArray)(&creditRoles))...)
+	if err != nil {
+		return nil, err
+	}
+
+	person.Age = person.AgeAt(time.Now())
+
+	person.Credits = make([]entity.Credit, len(creditMovieIDs))
+	for i := range creditMovieIDs {
+		person.Credits[i] = entity.Credit{MovieID: creditMovieIDs[i], Role: creditRoles[i]}
+	}
+
+	return &person, nil
+}
+
+func (s *Storage) GetPeople(limit, offset int, role string) ([]entity.Person, error) {
+	const op = "storage.postgres.GetPeople"
+
+	stmt, err := s.db.Prepare(personQuery + `
+		WHERE a.deleted_at IS NULL
+			AND ($1 = '' OR EXISTS (SELECT 1 FROM movie_crew mc WHERE mc.person_id = a.id AND mc.role::TEXT = $1))
+		ORDER BY a.id
+		LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(role, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var people []entity.Person
+	for rows.Next() {
+		person, err := scanPerson(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		people = append(people, *person)
+	}
+
+	return people, nil
+}
+
+func (s *Storage) GetPersonById(id int) (*entity.Person, error) {
+	const op = "storage.postgres.GetPersonById"
+
+	stmt, err := s.db.Prepare(personQuery + " WHERE a.id = $1 AND a.deleted_at IS NULL")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	person, err := scanPerson(stmt.QueryRow(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPersonNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return person, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAddCrewMember(t *testing.T) {
+	tests := []struct {
+		name  string
+		query fakeQuery
+		err   error
+	}{
+		{
+			name:  "Success",
+			query: fakeQuery{match: "INSERT INTO movie_crew", args: []driver.Value{int64(1), int64(2), "director"}, rows: [][]driver.Value{{true, true, true}}},
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "INSERT INTO movie_crew", rows: [][]driver.Value{{false, true, false}}},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Missing or deleted person",
+			query: fakeQuery{match: "INSERT INTO movie_crew", rows: [][]driver.Value{{true, false, false}}},
+			err:   storage.ErrPersonNotFound,
+		},
+		{
+			name:  "Duplicate credit",
+			query: fakeQuery{match: "INSERT INTO movie_crew", rows: [][]driver.Value{{true, true, false}}},
+			err:   storage.ErrCreditExists,
+		},
+		{
+			name:  "Movie purged concurrently",
+			query: fakeQuery{match: "INSERT INTO movie_crew", err: &pq.Error{Code: foreignKeyViolation, Constraint: "movie_crew_movie_id_fkey"}},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Person purged concurrently",
+			query: fakeQuery{match: "INSERT INTO movie_crew", err: &pq.Error{Code: foreignKeyViolation, Constraint: "movie_crew_person_id_fkey"}},
+			err:   storage.ErrPersonNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			err := s.AddCrewMember(1, &entity.CrewMember{PersonID: 2, Role: entity.CrewRoleDirector})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
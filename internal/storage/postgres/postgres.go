@@ -2,20 +2,360 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	_ "github.com/lib/pq"
 	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/cache"
 	"github.com/rmntim/movielab/internal/storage"
+	"strings"
+	"time"
+)
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	notNullViolation    = "23502"
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	checkViolation      = "23514"
 )
 
 type Storage struct {
 	db *sqlx.DB
+
+	// statsCache keeps results of statistics queries, it is nil when caching is disabled
+	statsCache *cache.Cache
+}
+
+type Option func(*Storage)
+
+// WithStatsCache enables caching of statistics for given time, zero ttl disables caching
+func WithStatsCache(ttl time.Duration) Option {
+	return func(s *Storage) {
+		if ttl > 0 {
+			s.statsCache = cache.New(ttl)
+		}
+	}
 }
 
-func New(storagePath string) (*Storage, error) {
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// liveMovie matches movie id from given column when the movie is not deleted
+func liveMovie(column string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM movies lm WHERE lm.id = %s AND lm.deleted_at IS NULL)", column)
+}
+
+// liveActor matches actor id from given column when the actor is not deleted
+func liveActor(column string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM actors la WHERE la.id = %s AND la.deleted_at IS NULL)", column)
+}
+
+// touch wraps statement changing records related to rows of table, which returns ids of the rows as `id`,
+// into one that also bumps update time of the rows, since their representation changes with related records.
+// Version is left as is, it only tracks fields of the row. Rows affected by the result are the touched rows.
+func touch(table, stmt string) string {
+	return fmt.Sprintf(`WITH changed AS (%s)
+			UPDATE %s SET updated_at = now() WHERE id IN (SELECT id FROM changed)`, stmt, table)
+}
+
+// touchActorMovies touches movies actor stars in or is credited in crew of, since their cast and crew
+// only list live actors
+func touchActorMovies(tx *sql.Tx, id int) error {
+	_, err := tx.Exec(
+		`UPDATE movies SET updated_at = now() WHERE id IN (
+				SELECT movie_id FROM movie_actors WHERE actor_id = $1
+				UNION SELECT movie_id FROM movie_crew WHERE person_id = $1)`, id)
+	return err
+}
+
+// touchMovieActors touches actors starring in movie, since their movies only list live movies
+func touchMovieActors(tx *sql.Tx, id int) error {
+	_, err := tx.Exec(`UPDATE actors SET updated_at = now() WHERE id IN (SELECT actor_id FROM movie_actors WHERE movie_id = $1)`, id)
+	return err
+}
+
+// movieCastColumn selects ids of live actors starring in movie `m`
+var movieCastColumn = `ARRAY(SELECT ma.actor_id FROM movie_actors ma WHERE ma.movie_id = m.id AND ` + liveActor("ma.actor_id") + `
+			ORDER BY ma.actor_id)`
+
+// movieColumns selects movie from table aliased as `m` in the order expected by scanMovie,
+// ids of deleted actors are left out
+var movieColumns = `m.id, m.title, m.description, m.release_date, m.rating, m.poster,
+		COALESCE(m.runtime, 0), m.countries, COALESCE(m.original_language, ''), m.certifications,
+		m.budget, m.budget_currency, m.box_office, m.box_office_currency, m.deleted_at, COALESCE(m.version, 0), m.updated_at,
+		` + movieCastColumn + `,
+		ARRAY(SELECT tg.name FROM movie_tags mtg JOIN tags tg ON tg.id = mtg.tag_id WHERE mtg.movie_id = m.id ORDER BY tg.name)`
+
+// scanMovie scans row selected with movieColumns, extra destinations are scanned after the movie
+func scanMovie(row rowScanner, movie *entity.Movie, extra ...any) error {
+	var (
+		certifications    []byte
+		budget, boxOffice nullMoney
+	)
+	dest := []any{&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Poster,
+		&movie.Runtime, (*pq.StringArray)(&movie.Countries), &movie.OriginalLanguage, &certifications,
+		&budget.amount, &budget.currency, &boxOffice.amount, &boxOffice.currency, &movie.DeletedAt, &movie.Version, &movie.UpdatedAt,
+		(*pq.Int32Array)(&movie.ActorIDs), (*pq.StringArray)(&movie.Tags)}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	movie.Budget = budget.money()
+	movie.BoxOffice = boxOffice.money()
+	return json.Unmarshal(certifications, &movie.Certifications)
+}
+
+// nullMoney scans nullable amount and currency columns
+type nullMoney struct {
+	amount   sql.NullInt64
+	currency sql.NullString
+}
+
+func (m nullMoney) money() *entity.Money {
+	if !m.amount.Valid {
+		return nil
+	}
+	return &entity.Money{Amount: m.amount.Int64, Currency: m.currency.String}
+}
+
+// moneyArgs returns amount and currency query arguments, which are NULL for nil money
+func moneyArgs(money *entity.Money) (any, any) {
+	if money == nil {
+		return nil, nil
+	}
+	return money.Amount, money.Currency
+}
+
+// movieMetadataArgs returns arguments for runtime, countries, original_language, certifications,
+// budget, budget_currency, box_office and box_office_currency columns
+func movieMetadataArgs(movie *entity.NewMovie) ([]any, error) {
+	certifications, err := json.Marshal(movie.Certifications)
+	if err != nil {
+		return nil, err
+	}
+	if movie.Certifications == nil {
+		certifications = []byte("{}")
+	}
+
+	countries := movie.Countries
+	if countries == nil {
+		countries = []string{}
+	}
+
+	budget, budgetCurrency := moneyArgs(movie.Budget)
+	boxOffice, boxOfficeCurrency := moneyArgs(movie.BoxOffice)
+
+	return []any{
+		sql.NullInt32{Int32: int32(movie.Runtime), Valid: movie.Runtime != 0},
+		pq.Array(countries),
+		sql.NullString{String: movie.OriginalLanguage, Valid: movie.OriginalLanguage != ""},
+		string(certifications),
+		budget, budgetCurrency, boxOffice, boxOfficeCurrency,
+	}, nil
+}
+
+// movieFilterClause builds WHERE clause for movies aliased as `m`, filter arguments are appended to given ones
+func movieFilterClause(filter *entity.MovieFilter, args []any) (string, []any) {
+	if filter == nil {
+		filter = &entity.MovieFilter{}
+	}
+
+	var conditions []string
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "m.deleted_at IS NULL")
+	}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.MinRuntime > 0 {
+		add("m.runtime >= $%d", filter.MinRuntime)
+	}
+	if filter.MaxRuntime > 0 {
+		add("m.runtime <= $%d", filter.MaxRuntime)
+	}
+	if filter.Country != "" {
+		add("$%d = ANY(m.countries)", filter.Country)
+	}
+	if filter.Language != "" {
+		add("m.original_language = $%d", filter.Language)
+	}
+	if filter.Certification != "" {
+		certification, _ := json.Marshal(map[string]string{filter.CertificationCountry: filter.Certification})
+		add("m.certifications @> $%d", string(certification))
+	} else if filter.CertificationCountry != "" {
+		add("m.certifications ? $%d", filter.CertificationCountry)
+	}
+
+	if filter.MinBudget > 0 {
+		add("m.budget >= $%d", filter.MinBudget)
+	}
+	if filter.MaxBudget > 0 {
+		add("m.budget <= $%d", filter.MaxBudget)
+	}
+	if filter.Currency != "" && (filter.MinBudget > 0 || filter.MaxBudget > 0) {
+		add("m.budget_currency = $%d", filter.Currency)
+	}
+	if filter.MinBoxOffice > 0 {
+		add("m.box_office >= $%d", filter.MinBoxOffice)
+	}
+	if filter.MaxBoxOffice > 0 {
+		add("m.box_office <= $%d", filter.MaxBoxOffice)
+	}
+	if filter.Currency != "" && (filter.MinBoxOffice > 0 || filter.MaxBoxOffice > 0) {
+		add("m.box_office_currency = $%d", filter.Currency)
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		conditions = append(conditions, tagMatch("m.id", "movie_tags", "movie_id", len(args)))
+	}
+
+	var awardConditions []string
+	awardConditions, args = awardFilterConditions(&filter.AwardFilter, "m.id", "movie_id", args)
+	conditions = append(conditions, awardConditions...)
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// tagMatch matches rows whose id is tagged with all of distinct tag names passed as parameter $n,
+// tags are looked up in given table linking column to tag_id
+func tagMatch(id, table, column string, n int) string {
+	return fmt.Sprintf(`%[1]s IN (SELECT tt.%[3]s FROM %[2]s tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tg.name = ANY($%[4]d)
+			GROUP BY tt.%[3]s
+			HAVING count(*) = cardinality($%[4]d::VARCHAR[]))`, id, table, column, n)
+}
+
+// awardFilterConditions builds conditions matching rows whose id is nominated by given column of nominations,
+// filter arguments are appended to given ones
+func awardFilterConditions(filter *entity.AwardFilter, id, column string, args []any) ([]string, []any) {
+	var conditions []string
+	nominations := fmt.Sprintf("FROM nominations n WHERE n.%s = %s", column, id)
+
+	won := ""
+	if filter.AwardWon {
+		won = " AND n.won"
+	}
+	if filter.AwardCategoryID > 0 {
+		args = append(args, filter.AwardCategoryID)
+		conditions = append(conditions,
+			fmt.Sprintf("EXISTS (SELECT 1 %s AND n.category_id = $%d%s)", nominations, len(args), won))
+	} else if filter.AwardWon {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 %s%s)", nominations, won))
+	}
+
+	if filter.MinNominations > 0 {
+		args = append(args, filter.MinNominations)
+		conditions = append(conditions, fmt.Sprintf("(SELECT count(*) %s) >= $%d", nominations, len(args)))
+	}
+	if filter.MinWins > 0 {
+		args = append(args, filter.MinWins)
+		conditions = append(conditions, fmt.Sprintf("(SELECT count(*) %s AND n.won) >= $%d", nominations, len(args)))
+	}
+
+	return conditions, args
+}
+
+// localizedMovieColumns is movieColumns with title and description taken from translation joined by movieTranslationJoin
+var localizedMovieColumns = strings.NewReplacer(
+	"m.title", "COALESCE(t.title, m.title)",
+	"m.description", "COALESCE(t.description, m.description)",
+).Replace(movieColumns)
+
+// movieTranslationJoin joins translation of movie `m` as `t`, picking the first of languages passed as parameter $n
+func movieTranslationJoin(n int) string {
+	return fmt.Sprintf(`LEFT JOIN LATERAL (
+			SELECT mt.title, mt.description FROM movie_translations mt
+			WHERE mt.movie_id = m.id AND mt.lang = ANY($%[1]d)
+			ORDER BY array_position($%[1]d, mt.lang) LIMIT 1) t ON TRUE`, n)
+}
+
+// newActorColumns selects fields of entity.NewActor from table aliased as `a` in the order of newActorDest
+const newActorColumns = `a.name, a.sex, COALESCE(a.gender, ''), a.birth_date, a.death_date,
+		COALESCE(a.birthplace, ''), COALESCE(a.biography, ''), a.aliases`
+
+func newActorDest(actor *entity.NewActor) []any {
+	return []any{&actor.Name, &actor.Sex, &actor.Gender, &actor.BirthDate, &actor.DeathDate,
+		&actor.Birthplace, &actor.Biography, (*pq.StringArray)(&actor.Aliases)}
+}
+
+// newActorArgs returns arguments for name, sex, gender, birth_date, death_date, birthplace, biography and aliases columns
+func newActorArgs(actor *entity.NewActor) []any {
+	aliases := actor.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return []any{actor.Name, actor.Sex,
+		sql.NullString{String: actor.Gender, Valid: actor.Gender != ""},
+		actor.BirthDate, actor.DeathDate,
+		sql.NullString{String: actor.Birthplace, Valid: actor.Birthplace != ""},
+		sql.NullString{String: actor.Biography, Valid: actor.Biography != ""},
+		pq.Array(aliases)}
+}
+
+// actorNameMatch matches actor `a` by name, translated names and aliases against ILIKE pattern passed as parameter $n
+func actorNameMatch(n int) string {
+	return fmt.Sprintf(`(a.name ILIKE $%[1]d
+			OR EXISTS (SELECT 1 FROM actor_translations act WHERE act.actor_id = a.id AND act.name ILIKE $%[1]d)
+			OR EXISTS (SELECT 1 FROM unnest(a.aliases) alias WHERE alias ILIKE $%[1]d))`, n)
+}
+
+// actorColumns selects actor from table aliased as `a` in the order expected by scanActor,
+// ids of deleted movies are left out
+var actorColumns = `a.id, ` + newActorColumns + `, a.headshot, a.deleted_at, a.version, a.updated_at,
+		ARRAY(SELECT ma.movie_id FROM movie_actors ma WHERE ma.actor_id = a.id AND ` + liveMovie("ma.movie_id") + `
+			ORDER BY ma.movie_id),
+		ARRAY(SELECT tg.name FROM actor_tags atg JOIN tags tg ON tg.id = atg.tag_id WHERE atg.actor_id = a.id ORDER BY tg.name)`
+
+// localizedActorColumns is actorColumns with name taken from translation joined by actorTranslationJoin
+var localizedActorColumns = strings.Replace(actorColumns, "a.name", "COALESCE(t.name, a.name)", 1)
+
+// actorTranslationJoin joins translation of actor `a` as `t`, picking the first of languages passed as parameter $n
+func actorTranslationJoin(n int) string {
+	return fmt.Sprintf(`LEFT JOIN LATERAL (
+			SELECT act.name FROM actor_translations act
+			WHERE act.actor_id = a.id AND act.lang = ANY($%[1]d)
+			ORDER BY array_position($%[1]d, act.lang) LIMIT 1) t ON TRUE`, n)
+}
+
+// scanActor scans row selected with actorColumns
+func scanActor(row rowScanner, actor *entity.Actor) error {
+	dest := append([]any{&actor.ID}, newActorDest(&actor.NewActor)...)
+	if err := row.Scan(append(dest, &actor.Headshot, &actor.DeletedAt, &actor.Version, &actor.UpdatedAt, (*pq.Int32Array)(&actor.MovieIDs), (*pq.StringArray)(&actor.Tags))...); err != nil {
+		return err
+	}
+
+	actor.Age = actor.AgeAt(time.Now())
+	return nil
+}
+
+// versionConflict tells why live row of table with given id wasn't changed by statement expecting its version,
+// it returns notFound if the row is gone, or storage.ErrVersionConflict if it has another version.
+func versionConflict(tx *sql.Tx, table string, id int, notFound error) error {
+	var exists bool
+	err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return notFound
+	}
+	return storage.ErrVersionConflict
+}
+
+func New(storagePath string, opts ...Option) (*Storage, error) {
 	const op = "storage.postgres.New"
 
 	db, err := sqlx.Open("postgres", storagePath)
@@ -28,7 +368,12 @@ func New(storagePath string) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &Storage{db: db}, nil
+	s := &Storage{db: db}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 func (s *Storage) GetUserRole(username string, password string) (string, error) {
 	const op = "storage.postgres.GetUserRole"
@@ -47,7 +392,8 @@ func (s *Storage) GetUserRole(username string, password string) (string, error)
 	return role, nil
 }
 
-func (s *Storage) GetMovies(limit, offset int, orderBy string, asc bool) ([]entity.Movie, error) {
+// GetMovies returns movies matching filter with title and description in the first available of given languages.
+func (s *Storage) GetMovies(limit, offset int, orderBy string, asc bool, filter *entity.MovieFilter, langs []string) ([]entity.Movie, error) {
 	const op = "storage.postgres.GetMovies"
 
 	orderDir := "DESC"
@@ -55,19 +401,17 @@ func (s *Storage) GetMovies(limit, offset int, orderBy string, asc bool) ([]enti
 		orderDir = "ASC"
 	}
 
+	where, args := movieFilterClause(filter, []any{orderBy, limit, offset, pq.Array(langs)})
 	query := fmt.Sprintf(
-		`SELECT m.*, array_remove(array_agg(a.id), NULL) FROM movies m
-				LEFT JOIN movie_actors ma ON ma.movie_id = m.id
-				LEFT JOIN actors a ON a.id = ma.actor_id
-				GROUP BY m.id
+		`SELECT %s FROM movies m %s %s
 				ORDER BY $1 %s LIMIT $2 OFFSET $3`,
-		orderDir)
+		localizedMovieColumns, movieTranslationJoin(4), where, orderDir)
 	stmt, err := s.db.Prepare(query)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rows, err := stmt.Query(orderBy, limit, offset)
+	rows, err := stmt.Query(args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -75,7 +419,7 @@ func (s *Storage) GetMovies(limit, offset int, orderBy string, asc bool) ([]enti
 	var movies []entity.Movie
 	for rows.Next() {
 		var movie entity.Movie
-		err = rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, (*pq.Int32Array)(&movie.ActorIDs))
+		err = scanMovie(rows, &movie)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -85,21 +429,19 @@ func (s *Storage) GetMovies(limit, offset int, orderBy string, asc bool) ([]enti
 	return movies, nil
 }
 
-func (s *Storage) GetMovieById(id int) (*entity.Movie, error) {
+// GetMovieById returns movie with title and description in the first available of given languages.
+func (s *Storage) GetMovieById(id int, langs []string) (*entity.Movie, error) {
 	const op = "storage.postgres.GetMovieById"
 
 	stmt, err := s.db.Prepare(
-		`SELECT m.*, array_remove(array_agg(a.id), NULL) FROM movies m
-				LEFT JOIN movie_actors ma ON m.id = ma.movie_id
-				LEFT JOIN actors a ON ma.actor_id = a.id
-				WHERE m.id = $1
-				GROUP BY m.id`)
+		`SELECT ` + localizedMovieColumns + ` FROM movies m ` + movieTranslationJoin(2) + `
+				WHERE m.id = $1 AND m.deleted_at IS NULL`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var movie entity.Movie
-	err = stmt.QueryRow(id).Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, (*pq.Int32Array)(&movie.ActorIDs))
+	err = scanMovie(stmt.QueryRow(id, pq.Array(langs)), &movie)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, storage.ErrMovieNotFound
@@ -107,10 +449,20 @@ func (s *Storage) GetMovieById(id int) (*entity.Movie, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	movie.Crew, err = s.getMovieCrew(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	movie.UserScore, err = s.getMovieUserScore(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return &movie, nil
 }
 
-func (s *Storage) CreateMovie(movie *entity.NewMovie) (int, error) {
+func (s *Storage) CreateMovie(movie *entity.NewMovie, audit entity.AuditInfo) (int, error) {
 	const op = "storage.postgres.CreateMovie"
 
 	tx, err := s.db.Begin()
@@ -119,18 +471,26 @@ func (s *Storage) CreateMovie(movie *entity.NewMovie) (int, error) {
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.Prepare("INSERT INTO movies (title, description, release_date, rating) VALUES ($1, $2, $3, $4) RETURNING id")
+	metadata, err := movieMetadataArgs(movie)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var id int
-	err = stmt.QueryRow(movie.Title, movie.Description, movie.ReleaseDate, movie.Rating).Scan(&id)
+	stmt, err := tx.Prepare(
+		`INSERT INTO movies (title, description, release_date, rating, runtime, countries, original_language,
+				certifications, budget, budget_currency, box_office, box_office_currency)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = tx.Prepare("INSERT INTO movie_actors (movie_id, actor_id) VALUES ($1, $2)")
+	var id int
+	err = stmt.QueryRow(append([]any{movie.Title, movie.Description, movie.ReleaseDate, movie.Rating}, metadata...)...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	stmt, err = tx.Prepare(touch("actors", "INSERT INTO movie_actors (movie_id, actor_id) VALUES ($1, $2) RETURNING actor_id AS id"))
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -138,10 +498,14 @@ func (s *Storage) CreateMovie(movie *entity.NewMovie) (int, error) {
 	for _, actorID := range movie.ActorIDs {
 		_, err = stmt.Exec(id, actorID)
 		if err != nil {
-			return 0, fmt.Errorf("%s: %w", op, err)
+			return 0, fmt.Errorf("%s: %w", op, constraintError(err))
 		}
 	}
 
+	if err := recordChange(tx, audit, entity.AuditCreate, entity.AuditMovie, id, nil); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -149,23 +513,56 @@ func (s *Storage) CreateMovie(movie *entity.NewMovie) (int, error) {
 	return id, nil
 }
 
-func (s *Storage) DeleteMovie(id int) error {
+// DeleteMovie marks movie as deleted, it keeps its cast and other links until purged.
+// Non-zero version must match current version of the movie. Missing or already deleted movie is ErrMovieNotFound.
+func (s *Storage) DeleteMovie(id, version int, audit entity.AuditInfo) error {
 	const op = "storage.postgres.DeleteMovie"
 
-	stmt, err := s.db.Prepare("DELETE FROM movies WHERE id = $1")
+	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback()
 
-	_, err = stmt.Exec(id)
+	before, err := snapshot(tx, entity.AuditMovie, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	res, err := tx.Exec(
+		`UPDATE movies SET deleted_at = now(), version = version + 1, updated_at = now()
+				WHERE id = $1 AND deleted_at IS NULL AND ($2::INT = 0 OR version = $2)`, id, version)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		if version != 0 {
+			return fmt.Errorf("%s: %w", op, versionConflict(tx, "movies", id, storage.ErrMovieNotFound))
+		}
+		return storage.ErrMovieNotFound
+	}
+
+	if err := touchMovieActors(tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordChange(tx, audit, entity.AuditDelete, entity.AuditMovie, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
-func (s *Storage) UpdateMovie(id int, movie *entity.Movie) error {
+func (s *Storage) UpdateMovie(id int, movie *entity.Movie, audit entity.AuditInfo) error {
 	const op = "storage.postgres.UpdateMovie"
 
 	tx, err := s.db.Begin()
@@ -174,27 +571,57 @@ func (s *Storage) UpdateMovie(id int, movie *entity.Movie) error {
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.Prepare("UPDATE movies SET title = $1, description = $2, release_date = $3, rating = $4 WHERE id = $5")
+	metadata, err := movieMetadataArgs(&movie.NewMovie)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = stmt.Exec(movie.Title, movie.Description, movie.ReleaseDate, movie.Rating, id)
+	before, err := snapshot(tx, entity.AuditMovie, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = tx.Prepare("DELETE FROM movie_actors WHERE movie_id = $1")
+	stmt, err := tx.Prepare(
+		`UPDATE movies SET title = $1, description = $2, release_date = $3, rating = $4, runtime = $5, countries = $6,
+				original_language = $7, certifications = $8, budget = $9, budget_currency = $10, box_office = $11,
+				box_office_currency = $12, version = version + 1, updated_at = now()
+				WHERE id = $13 AND deleted_at IS NULL AND version = $14
+				RETURNING version`)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	var version int
+	err = stmt.QueryRow(append(append([]any{movie.Title, movie.Description, movie.ReleaseDate, movie.Rating}, metadata...), id, movie.Version)...).
+		Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, versionConflict(tx, "movies", id, storage.ErrMovieNotFound))
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	// Links to deleted actors are not listed in movie, so they are kept for the actors to be restored.
+	// Actors leaving and joining the cast are touched, since their movies change.
+	stmt, err = tx.Prepare(touch("actors",
+		`DELETE FROM movie_actors ma WHERE ma.movie_id = $1 AND ma.actor_id <> ALL($2) AND `+liveActor("ma.actor_id")+`
+				RETURNING ma.actor_id AS id`))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Nil array would be NULL, which keeps the whole cast
+	cast := movie.ActorIDs
+	if cast == nil {
+		cast = []int32{}
+	}
+
+	_, err = stmt.Exec(id, pq.Int32Array(cast))
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = tx.Prepare("INSERT INTO movie_actors (movie_id, actor_id) VALUES ($1, $2)")
+	stmt, err = tx.Prepare(touch("actors", "INSERT INTO movie_actors (movie_id, actor_id) VALUES ($1, $2) ON CONFLICT DO NOTHING RETURNING actor_id AS id"))
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -202,32 +629,46 @@ func (s *Storage) UpdateMovie(id int, movie *entity.Movie) error {
 	for _, actorID := range movie.ActorIDs {
 		_, err = stmt.Exec(id, actorID)
 		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+			return fmt.Errorf("%s: %w", op, constraintError(err))
 		}
 	}
 
+	if err := recordChange(tx, audit, entity.AuditUpdate, entity.AuditMovie, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	movie.Version = version
+
 	return nil
 }
 
-func (s *Storage) SearchMovies(title, actorName string, limit, offset int) ([]entity.Movie, error) {
+// SearchMovies matches title and actor name in any language, found movies are returned in the first available of given languages.
+func (s *Storage) SearchMovies(title, actorName, director string, limit, offset int, langs []string) ([]entity.Movie, error) {
 	const op = "storage.postgres.SearchMovies"
 
 	stmt, err := s.db.Prepare(
-		`SELECT m.*, array_remove(array_agg(a.id), NULL) FROM movies m
+		`SELECT ` + localizedMovieColumns + ` FROM movies m
+				` + movieTranslationJoin(6) + `
 				LEFT JOIN movie_actors ma ON ma.movie_id = m.id
-				LEFT JOIN actors a ON a.id = ma.actor_id
-				WHERE m.title ILIKE $1 AND a.name ILIKE $2
-				GROUP BY m.id
-				LIMIT $3 OFFSET $4`)
+				LEFT JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+				WHERE m.deleted_at IS NULL AND (m.title ILIKE $1 OR EXISTS (
+						SELECT 1 FROM movie_translations mt WHERE mt.movie_id = m.id AND mt.title ILIKE $1))
+					AND ` + actorNameMatch(2) + `
+					AND ($3 = '' OR EXISTS (
+						SELECT 1 FROM movie_crew mc
+						JOIN actors p ON p.id = mc.person_id AND p.deleted_at IS NULL
+						WHERE mc.movie_id = m.id AND mc.role = 'director' AND p.name ILIKE '%' || $3 || '%'))
+				GROUP BY m.id, t.title, t.description
+				LIMIT $4 OFFSET $5`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rows, err := stmt.Query(fmt.Sprintf("%%%s%%", title), fmt.Sprintf("%%%s%%", actorName), limit, offset)
+	rows, err := stmt.Query(fmt.Sprintf("%%%s%%", title), fmt.Sprintf("%%%s%%", actorName), director, limit, offset, pq.Array(langs))
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -235,7 +676,7 @@ func (s *Storage) SearchMovies(title, actorName string, limit, offset int) ([]en
 	var movies []entity.Movie
 	for rows.Next() {
 		var movie entity.Movie
-		err = rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, (*pq.Int32Array)(&movie.ActorIDs))
+		err = scanMovie(rows, &movie)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -245,20 +686,37 @@ func (s *Storage) SearchMovies(title, actorName string, limit, offset int) ([]en
 	return movies, nil
 }
 
-func (s *Storage) GetActors(limit, offset int) ([]entity.Actor, error) {
+// GetActors returns actors matching filter with name in the first available of given languages.
+func (s *Storage) GetActors(limit, offset int, filter *entity.ActorFilter, langs []string) ([]entity.Actor, error) {
 	const op = "storage.postgres.GetActors"
 
+	if filter == nil {
+		filter = &entity.ActorFilter{}
+	}
+
+	args := []any{limit, offset, pq.Array(langs), fmt.Sprintf("%%%s%%", filter.Name)}
+	conditions := []string{actorNameMatch(4)}
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "a.deleted_at IS NULL")
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		conditions = append(conditions, tagMatch("a.id", "actor_tags", "actor_id", len(args)))
+	}
+
+	var awardConditions []string
+	awardConditions, args = awardFilterConditions(&filter.AwardFilter, "a.id", "actor_id", args)
+	where := strings.Join(append(conditions, awardConditions...), " AND ")
+
 	stmt, err := s.db.Prepare(
-		`SELECT a.*, array_remove(array_agg(m.id), NULL) FROM actors a
-				LEFT JOIN movie_actors ma ON ma.actor_id = a.id
-				LEFT JOIN movies m ON m.id = ma.movie_id
-				GROUP BY a.id
+		`SELECT ` + localizedActorColumns + ` FROM actors a ` + actorTranslationJoin(3) + `
+				WHERE ` + where + `
 				LIMIT $1 OFFSET $2`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rows, err := stmt.Query(limit, offset)
+	rows, err := stmt.Query(args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -266,7 +724,7 @@ func (s *Storage) GetActors(limit, offset int) ([]entity.Actor, error) {
 	var actors []entity.Actor
 	for rows.Next() {
 		var actor entity.Actor
-		err = rows.Scan(&actor.ID, &actor.Name, &actor.Sex, &actor.BirthDate, (*pq.Int32Array)(&actor.MovieIDs))
+		err = scanActor(rows, &actor)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -276,21 +734,18 @@ func (s *Storage) GetActors(limit, offset int) ([]entity.Actor, error) {
 	return actors, nil
 }
 
-func (s *Storage) GetActorById(id int) (*entity.Actor, error) {
+// GetActorById returns actor with name in the first available of given languages.
+func (s *Storage) GetActorById(id int, langs []string) (*entity.Actor, error) {
 	const op = "storage.postgres.GetActorByID"
 
-	stmt, err := s.db.Prepare(`
-		SELECT a.*, array_remove(array_agg(m.id), NULL) FROM actors a
-		LEFT JOIN movie_actors ma ON ma.actor_id = a.id
-		LEFT JOIN movies m ON m.id = ma.movie_id
-		WHERE a.id = $1
-		GROUP BY a.id`)
+	stmt, err := s.db.Prepare(`SELECT ` + localizedActorColumns + ` FROM actors a ` + actorTranslationJoin(2) + `
+				WHERE a.id = $1 AND a.deleted_at IS NULL`)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var actor entity.Actor
-	err = stmt.QueryRow(id).Scan(&actor.ID, &actor.Name, &actor.Sex, &actor.BirthDate, (*pq.Int32Array)(&actor.MovieIDs))
+	err = scanActor(stmt.QueryRow(id, pq.Array(langs)), &actor)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, storage.ErrActorNotFound
@@ -301,51 +756,155 @@ func (s *Storage) GetActorById(id int) (*entity.Actor, error) {
 	return &actor, nil
 }
 
-func (s *Storage) CreateActor(actor *entity.NewActor) (int, error) {
+// GetMissingActorIDs returns those of given ids, in given order, that don't belong to live actors.
+func (s *Storage) GetMissingActorIDs(ids []int32) ([]int32, error) {
+	const op = "storage.postgres.GetMissingActorIDs"
+
+	rows, err := s.db.Query(`SELECT ids.id FROM unnest($1::int[]) WITH ORDINALITY AS ids(id, n)
+				WHERE NOT `+liveActor("ids.id")+` ORDER BY ids.n`, pq.Int32Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	missing := make([]int32, 0)
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		missing = append(missing, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return missing, nil
+}
+
+func (s *Storage) CreateActor(actor *entity.NewActor, audit entity.AuditInfo) (int, error) {
 	const op = "storage.postgres.CreateActor"
 
-	stmt, err := s.db.Prepare("INSERT INTO actors (name, sex, birth_date) VALUES ($1, $2, $3) RETURNING id")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO actors (name, sex, gender, birth_date, death_date, birthplace, biography, aliases)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var id int
-	err = stmt.QueryRow(actor.Name, actor.Sex, actor.BirthDate).Scan(&id)
+	err = stmt.QueryRow(newActorArgs(actor)...).Scan(&id)
 	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	if err := recordChange(tx, audit, entity.AuditCreate, entity.AuditActor, id, nil); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return id, nil
 }
 
-func (s *Storage) DeleteActor(id int) error {
+// DeleteActor marks actor as deleted, it keeps their movies and other links until purged.
+// Non-zero version must match current version of the actor. Missing or already deleted actor is ErrActorNotFound.
+func (s *Storage) DeleteActor(id, version int, audit entity.AuditInfo) error {
 	const op = "storage.postgres.DeleteActor"
 
-	stmt, err := s.db.Prepare("DELETE FROM actors WHERE id = $1")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshot(tx, entity.AuditActor, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = stmt.Exec(id)
+	res, err := tx.Exec(
+		`UPDATE actors SET deleted_at = now(), version = version + 1, updated_at = now()
+				WHERE id = $1 AND deleted_at IS NULL AND ($2::INT = 0 OR version = $2)`, id, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		if version != 0 {
+			return fmt.Errorf("%s: %w", op, versionConflict(tx, "actors", id, storage.ErrActorNotFound))
+		}
+		return storage.ErrActorNotFound
+	}
+
+	if err := touchActorMovies(tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordChange(tx, audit, entity.AuditDelete, entity.AuditActor, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
-func (s *Storage) UpdateActor(id int, actor *entity.Actor) error {
+func (s *Storage) UpdateActor(id int, actor *entity.Actor, audit entity.AuditInfo) error {
 	const op = "storage.postgres.UpdateActor"
 
-	stmt, err := s.db.Prepare("UPDATE actors SET name = $1, sex = $2, birth_date = $3 WHERE id = $4")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshot(tx, entity.AuditActor, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = stmt.Exec(actor.Name, actor.Sex, actor.BirthDate, id)
+	stmt, err := tx.Prepare(
+		`UPDATE actors SET name = $1, sex = $2, gender = $3, birth_date = $4, death_date = $5, birthplace = $6,
+				biography = $7, aliases = $8, version = version + 1, updated_at = now()
+				WHERE id = $9 AND deleted_at IS NULL AND version = $10
+				RETURNING version`)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	var version int
+	err = stmt.QueryRow(append(newActorArgs(&actor.NewActor), id, actor.Version)...).Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, versionConflict(tx, "actors", id, storage.ErrActorNotFound))
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	if err := recordChange(tx, audit, entity.AuditUpdate, entity.AuditActor, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	actor.Version = version
+
 	return nil
 }
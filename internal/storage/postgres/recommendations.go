@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"math"
+	"strings"
+)
+
+// Maximum contribution of each signal to recommendation score, scores add up to at most 1
+const (
+	sharedCastWeight = 0.6
+	releaseEraWeight = 0.25
+	ratingWeight     = 0.15
+
+	// sharedCastSaturation is number of shared actors that gives full shared cast score
+	sharedCastSaturation = 3
+	// releaseEraSpan is gap in years after which release era gives no score
+	releaseEraSpan = 20
+)
+
+// recommendationQuery scores movies against seed movies selected by given query, which must return `movie_id` column.
+// Seed movies themselves are never recommended. Parameters $1 and $2 are limit and offset, seed query parameters
+// start with $3.
+//
+// Scores are computed from shared cast with any of the seed movies, release year gap
+// and rating gap to seed movies averages, see recommendationReasons.
+func recommendationQuery(seeds string) string {
+	return strings.NewReplacer(
+		"{seeds}", seeds,
+		"{cast_weight}", fmt.Sprint(sharedCastWeight),
+		"{era_weight}", fmt.Sprint(releaseEraWeight),
+		"{rating_weight}", fmt.Sprint(ratingWeight),
+		"{cast_saturation}", fmt.Sprint(sharedCastSaturation),
+		"{era_span}", fmt.Sprint(releaseEraSpan),
+	).Replace(
+		`WITH seeds AS ({seeds}),
+			profile AS (
+				SELECT avg(extract(YEAR FROM m.release_date)) AS year, avg(m.rating) AS rating
+				FROM movies m
+				WHERE m.id IN (SELECT movie_id FROM seeds) AND m.deleted_at IS NULL
+			),
+			shared AS (
+				SELECT other.movie_id, array_agg(DISTINCT a.name ORDER BY a.name) AS names
+				FROM movie_actors own
+				JOIN movie_actors other ON other.actor_id = own.actor_id
+				JOIN actors a ON a.id = own.actor_id AND a.deleted_at IS NULL
+				WHERE own.movie_id IN (SELECT movie_id FROM seeds) AND ` + liveMovie("own.movie_id") + `
+					AND other.movie_id NOT IN (SELECT movie_id FROM seeds)
+				GROUP BY other.movie_id
+			),
+			features AS (
+				SELECT m.id,
+					COALESCE(sh.names, '{}') AS shared_cast,
+					abs(extract(YEAR FROM m.release_date) - p.year)::float8 AS year_gap,
+					abs(m.rating - p.rating)::float8 AS rating_gap
+				FROM movies m
+				CROSS JOIN profile p
+				LEFT JOIN shared sh ON sh.movie_id = m.id
+				WHERE p.year IS NOT NULL AND m.id NOT IN (SELECT movie_id FROM seeds) AND m.deleted_at IS NULL
+			),
+			scored AS (
+				SELECT f.*,
+					{cast_weight} * least(cardinality(f.shared_cast), {cast_saturation})::float8 / {cast_saturation} AS cast_score,
+					{era_weight} * greatest(0, 1 - f.year_gap / {era_span}) AS era_score,
+					{rating_weight} * greatest(0, 1 - f.rating_gap / 10) AS rating_score
+				FROM features f
+			)
+		SELECT ` + movieColumns + `, sc.shared_cast, sc.year_gap, sc.rating_gap, sc.cast_score, sc.era_score, sc.rating_score
+		FROM scored sc
+		JOIN movies m ON m.id = sc.id
+		ORDER BY sc.cast_score + sc.era_score + sc.rating_score DESC, m.id
+		LIMIT $1 OFFSET $2`)
+}
+
+func (s *Storage) movieExists(id int) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM movies WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	return exists, err
+}
+
+// GetSimilarMovies returns movies ranked by similarity to given movie.
+func (s *Storage) GetSimilarMovies(movieID, limit, offset int) ([]entity.Recommendation, error) {
+	const op = "storage.postgres.GetSimilarMovies"
+
+	exists, err := s.movieExists(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, storage.ErrMovieNotFound
+	}
+
+	recommendations, err := s.getRecommendations(`SELECT $3::int AS movie_id`, limit, offset, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recommendations, nil
+}
+
+// GetRecommendations returns movies ranked by similarity to movies user has watched,
+// added to watchlist or reviewed favorably. Users without such movies get no recommendations.
+func (s *Storage) GetRecommendations(username string, limit, offset int) ([]entity.Recommendation, error) {
+	const op = "storage.postgres.GetRecommendations"
+
+	recommendations, err := s.getRecommendations(
+		`SELECT movie_id FROM watch_history WHERE username = $3
+				UNION SELECT movie_id FROM watchlist WHERE username = $3
+				UNION SELECT movie_id FROM reviews WHERE username = $3 AND rating >= 6`,
+		limit, offset, username)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recommendations, nil
+}
+
+func (s *Storage) getRecommendations(seeds string, limit, offset int, seedArgs ...any) ([]entity.Recommendation, error) {
+	stmt, err := s.db.Prepare(recommendationQuery(seeds))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(append([]any{limit, offset}, seedArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recommendations []entity.Recommendation
+	for rows.Next() {
+		var (
+			recommendation                   entity.Recommendation
+			sharedCast                       []string
+			yearGap, ratingGap               float64
+			castScore, eraScore, ratingScore float64
+		)
+		err = scanMovie(rows, &recommendation.Movie, (*pq.StringArray)(&sharedCast), &yearGap, &ratingGap,
+			&castScore, &eraScore, &ratingScore)
+		if err != nil {
+			return nil, err
+		}
+
+		recommendation.Reasons = recommendationReasons(sharedCast, yearGap, ratingGap, castScore, eraScore, ratingScore)
+		for _, reason := range recommendation.Reasons {
+			recommendation.Score += reason.Score
+		}
+		recommendation.Score = roundScore(recommendation.Score)
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return recommendations, rows.Err()
+}
+
+// recommendationReasons explains non-zero scores of a recommended movie
+func recommendationReasons(sharedCast []string, yearGap, ratingGap, castScore, eraScore, ratingScore float64) []entity.RecommendationReason {
+	reasons := []entity.RecommendationReason{}
+
+	if castScore > 0 {
+		reasons = append(reasons, entity.RecommendationReason{
+			Kind:        entity.ReasonSharedCast,
+			Score:       roundScore(castScore),
+			Description: "Also starring " + strings.Join(sharedCast, ", "),
+		})
+	}
+	if eraScore > 0 {
+		description := "Released in the same year"
+		if years := math.Round(yearGap); years == 1 {
+			description = "Released within a year"
+		} else if years > 1 {
+			description = fmt.Sprintf("Released within %d years", int(years))
+		}
+		reasons = append(reasons, entity.RecommendationReason{
+			Kind:        entity.ReasonReleaseEra,
+			Score:       roundScore(eraScore),
+			Description: description,
+		})
+	}
+	if ratingScore > 0 {
+		description := "Same rating"
+		if ratingGap >= 0.05 {
+			description = fmt.Sprintf("Rating differs by %.1f", ratingGap)
+		}
+		reasons = append(reasons, entity.RecommendationReason{
+			Kind:        entity.ReasonRating,
+			Score:       roundScore(ratingScore),
+			Description: description,
+		})
+	}
+
+	return reasons
+}
+
+// roundScore rounds score to three decimal places
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
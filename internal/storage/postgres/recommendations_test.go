@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRecommendationReasons(t *testing.T) {
+	tests := []struct {
+		name        string
+		sharedCast  []string
+		yearGap     float64
+		ratingGap   float64
+		castScore   float64
+		eraScore    float64
+		ratingScore float64
+		reasons     []entity.RecommendationReason
+	}{
+		{
+			name:        "All signals",
+			sharedCast:  []string{"Keanu Reeves", "Laurence Fishburne"},
+			yearGap:     4,
+			ratingGap:   1.5,
+			castScore:   0.4,
+			eraScore:    0.2,
+			ratingScore: 0.1275,
+			reasons: []entity.RecommendationReason{
+				{Kind: entity.ReasonSharedCast, Score: 0.4, Description: "Also starring Keanu Reeves, Laurence Fishburne"},
+				{Kind: entity.ReasonReleaseEra, Score: 0.2, Description: "Released within 4 years"},
+				{Kind: entity.ReasonRating, Score: 0.128, Description: "Rating differs by 1.5"},
+			},
+		},
+		{
+			name:        "Same year and rating",
+			ratingScore: 0.15,
+			eraScore:    0.25,
+			reasons: []entity.RecommendationReason{
+				{Kind: entity.ReasonReleaseEra, Score: 0.25, Description: "Released in the same year"},
+				{Kind: entity.ReasonRating, Score: 0.15, Description: "Same rating"},
+			},
+		},
+		{
+			name:      "Distant era",
+			yearGap:   25,
+			ratingGap: 10,
+			reasons:   []entity.RecommendationReason{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reasons := recommendationReasons(tt.sharedCast, tt.yearGap, tt.ratingGap, tt.castScore, tt.eraScore, tt.ratingScore)
+			require.Equal(t, tt.reasons, reasons)
+		})
+	}
+}
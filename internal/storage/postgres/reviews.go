@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+)
+
+const (
+	minReviewRating = 0
+	maxReviewRating = 10
+)
+
+func (s *Storage) getMovieUserScore(movieID int) (*entity.UserScore, error) {
+	const op = "storage.postgres.getMovieUserScore"
+
+	stmt, err := s.db.Prepare("SELECT rating, count(*) FROM reviews WHERE movie_id = $1 GROUP BY rating")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	score := entity.UserScore{
+		Distribution: make([]int, maxReviewRating-minReviewRating+1),
+	}
+	var sum int
+	for rows.Next() {
+		var rating, count int
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		score.Distribution[rating-minReviewRating] = count
+		score.Count += count
+		sum += rating * count
+	}
+
+	if score.Count > 0 {
+		score.Mean = float64(sum) / float64(score.Count)
+	}
+
+	return &score, nil
+}
+
+const reviewQuery = `SELECT id, movie_id, username, rating, COALESCE(text, ''), created_at, updated_at FROM reviews`
+
+func scanReview(row rowScanner) (*entity.Review, error) {
+	var review entity.Review
+	err := row.Scan(&review.ID, &review.MovieID, &review.Username, &review.Rating, &review.Text, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (s *Storage) GetReviews(movieID, limit, offset int) ([]entity.Review, error) {
+	const op = "storage.postgres.GetReviews"
+
+	stmt, err := s.db.Prepare(reviewQuery + " WHERE movie_id = $1 AND " + liveMovie("movie_id") + `
+			ORDER BY updated_at DESC LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(movieID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reviews []entity.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, nil
+}
+
+func (s *Storage) GetReviewById(id int) (*entity.Review, error) {
+	const op = "storage.postgres.GetReviewById"
+
+	stmt, err := s.db.Prepare(reviewQuery + " WHERE id = $1 AND " + liveMovie("movie_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	review, err := scanReview(stmt.QueryRow(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return review, nil
+}
+
+// CreateReview adds review of live movie, it returns storage.ErrMovieNotFound for missing and deleted movies
+func (s *Storage) CreateReview(movieID int, username string, review *entity.NewReview) (*entity.Review, error) {
+	const op = "storage.postgres.CreateReview"
+
+	stmt, err := s.db.Prepare(
+		`WITH movie AS (SELECT id FROM movies WHERE id = $1 AND deleted_at IS NULL),
+				review AS (
+					INSERT INTO reviews (movie_id, username, rating, text) SELECT id, $2, $3, $4 FROM movie
+					RETURNING id, movie_id, username, rating, COALESCE(text, '') AS text, created_at, updated_at),
+				touched AS (UPDATE movies SET updated_at = now() WHERE id IN (SELECT movie_id FROM review))
+				SELECT * FROM review`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := scanReview(stmt.QueryRow(movieID, username, review.Rating, review.Text))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, storage.ErrReviewExists
+		}
+		// Movie purged after the check fails on the foreign key
+		if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return nil, storage.ErrMovieNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return created, nil
+}
+
+func (s *Storage) UpdateReview(id int, review *entity.NewReview) (*entity.Review, error) {
+	const op = "storage.postgres.UpdateReview"
+
+	stmt, err := s.db.Prepare(
+		`WITH review AS (
+					UPDATE reviews SET rating = $1, text = $2, updated_at = now() WHERE id = $3
+					RETURNING id, movie_id, username, rating, COALESCE(text, '') AS text, created_at, updated_at),
+				touched AS (UPDATE movies SET updated_at = now() WHERE id IN (SELECT movie_id FROM review))
+				SELECT * FROM review`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := scanReview(stmt.QueryRow(review.Rating, review.Text, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return updated, nil
+}
+
+func (s *Storage) DeleteReview(id int) error {
+	const op = "storage.postgres.DeleteReview"
+
+	stmt, err := s.db.Prepare(touch("movies", "DELETE FROM reviews WHERE id = $1 RETURNING movie_id AS id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrReviewNotFound
+	}
+
+	return nil
+}
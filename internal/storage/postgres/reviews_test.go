@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateReview(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query fakeQuery
+		err   error
+	}{
+		{
+			name: "Success",
+			query: fakeQuery{match: "deleted_at IS NULL", args: []driver.Value{int64(1), "user", int64(8), "Great"},
+				rows: [][]driver.Value{{int64(3), int64(1), "user", int64(8), "Great", created, created}}},
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "INSERT INTO reviews"},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Movie purged concurrently",
+			query: fakeQuery{match: "INSERT INTO reviews", err: &pq.Error{Code: foreignKeyViolation}},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Review exists",
+			query: fakeQuery{match: "INSERT INTO reviews", err: &pq.Error{Code: uniqueViolation}},
+			err:   storage.ErrReviewExists,
+		},
+		{
+			name:  "Rating out of range",
+			query: fakeQuery{match: "INSERT INTO reviews", err: &pq.Error{Code: checkViolation, Constraint: "reviews_rating_check"}},
+			err:   storage.ErrConstraintViolated,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			review, err := s.CreateReview(1, "user", &entity.NewReview{Rating: 8, Text: "Great"})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &entity.Review{ID: 3, MovieID: 1, Username: "user", NewReview: entity.NewReview{Rating: 8, Text: "Great"},
+				CreatedAt: created, UpdatedAt: created}, review)
+		})
+	}
+}
+
+func TestDeleteReview(t *testing.T) {
+	tests := []struct {
+		name  string
+		query fakeQuery
+		err   error
+	}{
+		{
+			// Movie of the review is touched, since its user score changes
+			name:  "Success",
+			query: fakeQuery{match: "UPDATE movies SET updated_at = now()", args: []driver.Value{int64(3)}, affected: 1},
+		},
+		{
+			name:  "Missing review",
+			query: fakeQuery{match: "DELETE FROM reviews"},
+			err:   storage.ErrReviewNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			err := s.DeleteReview(3)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"strings"
+	"time"
+)
+
+// revisionMovieColumns is movieColumns for movie `m` rebuilt from revision `r`, with cast of the revision,
+// old revisions don't have update time, so time of the revision is used instead
+var revisionMovieColumns = strings.NewReplacer(
+	"m.updated_at", "r.created_at",
+	movieCastColumn, `ARRAY(SELECT rc.actor_id FROM movie_cast_revisions rc
+			WHERE rc.movie_id = r.movie_id AND rc.revision = r.revision ORDER BY rc.actor_id)`,
+).Replace(movieColumns)
+
+// revisionMovie rebuilds movie `m` from data of revision `r`, columns missing in old revisions are null
+const revisionMovie = `movie_revisions r, jsonb_populate_record(NULL::movies, r.data) m`
+
+// writeRevision saves snapshot of entity as its next revision, links are stored in their own revision tables.
+func writeRevision(tx *sql.Tx, audit entity.AuditInfo, entityType string, id int, data []byte) error {
+	username := sql.NullString{String: audit.Username, Valid: audit.Username != ""}
+
+	switch entityType {
+	case entity.AuditMovie:
+		var revision int
+		err := tx.QueryRow(
+			`INSERT INTO movie_revisions (movie_id, revision, data, username)
+					SELECT $1::INT, COALESCE(MAX(revision), 0) + 1, $2::JSONB - 'actor_ids', $3
+					FROM movie_revisions WHERE movie_id = $1
+					RETURNING revision`, id, string(data), username).Scan(&revision)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
+			`INSERT INTO movie_cast_revisions (movie_id, revision, actor_id)
+					SELECT movie_id, $2::INT, actor_id FROM movie_actors WHERE movie_id = $1`, id, revision)
+		return err
+	case entity.AuditActor:
+		_, err := tx.Exec(
+			`INSERT INTO actor_revisions (actor_id, revision, data, username)
+					SELECT $1::INT, COALESCE(MAX(revision), 0) + 1, $2::JSONB - 'movie_ids', $3
+					FROM actor_revisions WHERE actor_id = $1`, id, string(data), username)
+		return err
+	}
+
+	return fmt.Errorf("unknown entity type %q", entityType)
+}
+
+// GetMovieRevisions returns revisions of movie, deleted or not, newest first.
+func (s *Storage) GetMovieRevisions(id, limit, offset int) ([]entity.MovieRevision, error) {
+	const op = "storage.postgres.GetMovieRevisions"
+
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM movies WHERE id = $1)", id).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, storage.ErrMovieNotFound
+	}
+
+	stmt, err := s.db.Prepare(
+		`SELECT ` + revisionMovieColumns + `, r.revision, COALESCE(r.username, ''), r.created_at
+				FROM ` + revisionMovie + `
+				WHERE r.movie_id = $1
+				ORDER BY r.revision DESC
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	revisions := []entity.MovieRevision{}
+	for rows.Next() {
+		var revision entity.MovieRevision
+		err = scanMovie(rows, &revision.Movie, &revision.Revision, &revision.Username, &revision.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// GetMovieAsOf returns movie as it was at given time, with original title and description.
+// Movie that didn't exist or was deleted at that time is not found.
+func (s *Storage) GetMovieAsOf(id int, asOf time.Time) (*entity.Movie, error) {
+	const op = "storage.postgres.GetMovieAsOf"
+
+	stmt, err := s.db.Prepare(
+		`SELECT ` + revisionMovieColumns + `
+				FROM ` + revisionMovie + `
+				WHERE r.movie_id = $1 AND r.created_at <= $2
+				ORDER BY r.revision DESC
+				LIMIT 1`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var movie entity.Movie
+	err = scanMovie(stmt.QueryRow(id, asOf), &movie)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrMovieNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if movie.DeletedAt != nil {
+		return nil, storage.ErrMovieNotFound
+	}
+
+	return &movie, nil
+}
+
+// RestoreMovieRevision reverts fields and cast of live movie to given revision, which is saved as a new revision.
+// Poster is kept, as well as links to deleted actors and actors that are gone since.
+func (s *Storage) RestoreMovieRevision(id, revision int, audit entity.AuditInfo) error {
+	const op = "storage.postgres.RestoreMovieRevision"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var data []byte
+	err = tx.QueryRow("SELECT data FROM movie_revisions WHERE movie_id = $1 AND revision = $2", id, revision).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrRevisionNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	before, err := snapshot(tx, entity.AuditMovie, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec(
+		`UPDATE movies m SET title = r.title, description = r.description, release_date = r.release_date,
+				rating = r.rating, runtime = r.runtime, countries = COALESCE(r.countries, '{}'),
+				original_language = r.original_language, certifications = COALESCE(r.certifications, '{}'),
+				budget = r.budget, budget_currency = r.budget_currency, box_office = r.box_office,
+				box_office_currency = r.box_office_currency, version = m.version + 1, updated_at = now()
+				FROM jsonb_populate_record(NULL::movies, $2::JSONB) r
+				WHERE m.id = $1 AND m.deleted_at IS NULL`, id, string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrMovieNotFound
+	}
+
+	// Actors leaving and joining the cast are touched, since their movies change
+	_, err = tx.Exec(touch("actors",
+		`DELETE FROM movie_actors ma WHERE ma.movie_id = $1 AND `+liveActor("ma.actor_id")+`
+				AND ma.actor_id NOT IN (SELECT rc.actor_id FROM movie_cast_revisions rc WHERE rc.movie_id = $1 AND rc.revision = $2)
+				RETURNING ma.actor_id AS id`), id, revision)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(touch("actors",
+		`INSERT INTO movie_actors (movie_id, actor_id)
+				SELECT $1::INT, rc.actor_id FROM movie_cast_revisions rc
+				JOIN actors a ON a.id = rc.actor_id AND a.deleted_at IS NULL
+				WHERE rc.movie_id = $1 AND rc.revision = $2
+				ON CONFLICT DO NOTHING
+				RETURNING actor_id AS id`), id, revision)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordChange(tx, audit, entity.AuditRevert, entity.AuditMovie, id, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
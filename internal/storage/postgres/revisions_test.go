@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRestoreMovieRevision(t *testing.T) {
+	audit := entity.AuditInfo{Username: "admin", RequestID: "req-1"}
+	revisionData := []driver.Value{[]byte(`{"id": 1, "title": "Heat"}`)}
+
+	tests := []struct {
+		name    string
+		queries []fakeQuery
+		err     error
+		// committed tells if restore is expected to be committed
+		committed bool
+	}{
+		{
+			name: "Success",
+			queries: []fakeQuery{
+				{match: "SELECT data FROM movie_revisions", args: []driver.Value{int64(1), int64(2)}, rows: [][]driver.Value{revisionData}},
+				{match: "FROM movies m WHERE m.id = $1", args: []driver.Value{int64(1)}, rows: [][]driver.Value{{[]byte(`{"id": 1, "title": "Ronin"}`)}}},
+				{match: "UPDATE movies m SET", affected: 1},
+				{match: "DELETE FROM movie_actors", args: []driver.Value{int64(1), int64(2)}, affected: 1},
+				{match: "INSERT INTO movie_actors", args: []driver.Value{int64(1), int64(2)}, affected: 1},
+				{match: "FROM movies m WHERE m.id = $1", rows: [][]driver.Value{{[]byte(`{"id": 1, "title": "Heat"}`)}}},
+				{match: "INSERT INTO audit_log"},
+				{match: "INSERT INTO movie_revisions", rows: [][]driver.Value{{int64(3)}}},
+				{match: "INSERT INTO movie_cast_revisions", args: []driver.Value{int64(1), int64(3)}},
+			},
+			committed: true,
+		},
+		{
+			name: "Revision not found",
+			queries: []fakeQuery{
+				{match: "SELECT data FROM movie_revisions"},
+			},
+			err: storage.ErrRevisionNotFound,
+		},
+		{
+			name: "Movie not found",
+			queries: []fakeQuery{
+				{match: "SELECT data FROM movie_revisions", rows: [][]driver.Value{revisionData}},
+				{match: "FROM movies m WHERE m.id = $1"},
+				{match: "UPDATE movies m SET"},
+			},
+			err: storage.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, db := newFakeStorage(t, tt.queries...)
+
+			err := s.RestoreMovieRevision(1, 2, audit)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.committed, db.committed)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/lib/cache"
+)
+
+// summaryColumns summarizes column `v`, in the order of summaryDest
+const summaryColumns = `count(v), COALESCE(avg(v), 0), COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY v), 0),
+		COALESCE(min(v), 0), COALESCE(max(v), 0)`
+
+func summaryDest(summary *entity.Summary) []any {
+	return []any{&summary.Count, &summary.Average, &summary.Median, &summary.Min, &summary.Max}
+}
+
+// GetMovieCountsByYear returns number of movies released in each year, years without movies are omitted.
+func (s *Storage) GetMovieCountsByYear() ([]entity.YearCount, error) {
+	const op = "storage.postgres.GetMovieCountsByYear"
+
+	counts, err := cache.Get(s.statsCache, "movies_per_year", func() ([]entity.YearCount, error) {
+		rows, err := s.db.Query(
+			`SELECT extract(YEAR FROM release_date)::int AS year, count(*)
+					FROM movies
+					WHERE deleted_at IS NULL
+					GROUP BY year
+					ORDER BY year`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		counts := []entity.YearCount{}
+		for rows.Next() {
+			var count entity.YearCount
+			if err := rows.Scan(&count.Year, &count.Count); err != nil {
+				return nil, err
+			}
+			counts = append(counts, count)
+		}
+		return counts, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// GetRatingDistribution returns number of movies and reviews for each rating from 0 to 10.
+func (s *Storage) GetRatingDistribution() (*entity.RatingDistribution, error) {
+	const op = "storage.postgres.GetRatingDistribution"
+
+	distribution, err := cache.Get(s.statsCache, "ratings", func() (*entity.RatingDistribution, error) {
+		movies, err := s.getRatingCounts("movies", "t.deleted_at IS NULL")
+		if err != nil {
+			return nil, err
+		}
+		reviews, err := s.getRatingCounts("reviews", liveMovie("t.movie_id"))
+		if err != nil {
+			return nil, err
+		}
+		return &entity.RatingDistribution{Movies: movies, Reviews: reviews}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return distribution, nil
+}
+
+// getRatingCounts counts rows of table aliased as `t` matching condition by rating, including ratings without rows
+func (s *Storage) getRatingCounts(table, condition string) ([]entity.RatingCount, error) {
+	rows, err := s.db.Query(
+		`SELECT r.rating, count(t.rating)
+				FROM generate_series(0, 10) AS r(rating)
+				LEFT JOIN ` + table + ` t ON t.rating = r.rating AND ` + condition + `
+				GROUP BY r.rating
+				ORDER BY r.rating`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []entity.RatingCount
+	for rows.Next() {
+		var count entity.RatingCount
+		if err := rows.Scan(&count.Rating, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// GetProlificActors returns actors who played in most movies.
+func (s *Storage) GetProlificActors(limit int) ([]entity.ProlificActor, error) {
+	const op = "storage.postgres.GetProlificActors"
+
+	actors, err := cache.Get(s.statsCache, fmt.Sprintf("prolific_actors:%d", limit), func() ([]entity.ProlificActor, error) {
+		rows, err := s.db.Query(
+			`SELECT a.id, a.name, count(*)
+					FROM movie_actors ma
+					JOIN movies m ON m.id = ma.movie_id AND m.deleted_at IS NULL
+					JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+					GROUP BY a.id
+					ORDER BY count(*) DESC, a.id
+					LIMIT $1`, limit)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		actors := []entity.ProlificActor{}
+		for rows.Next() {
+			var actor entity.ProlificActor
+			if err := rows.Scan(&actor.ID, &actor.Name, &actor.Movies); err != nil {
+				return nil, err
+			}
+			actors = append(actors, actor)
+		}
+		return actors, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return actors, nil
+}
+
+// GetCastSizeStats summarizes number of actors per movie, movies without cast are counted as well.
+func (s *Storage) GetCastSizeStats() (*entity.Summary, error) {
+	const op = "storage.postgres.GetCastSizeStats"
+
+	summary, err := cache.Get(s.statsCache, "cast_size", func() (*entity.Summary, error) {
+		var summary entity.Summary
+		err := s.db.QueryRow(
+			`SELECT ` + summaryColumns + `
+					FROM (SELECT count(ma.actor_id) AS v
+						FROM movies m
+						LEFT JOIN movie_actors ma ON ma.movie_id = m.id AND ` + liveActor("ma.actor_id") + `
+						WHERE m.deleted_at IS NULL
+						GROUP BY m.id) sizes`).
+			Scan(summaryDest(&summary)...)
+		return &summary, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return summary, nil
+}
+
+// GetActorAgeStats summarizes age of actors in full years at release of movies they played in.
+// Appearances in movies released before actor's birth date are ignored.
+func (s *Storage) GetActorAgeStats() (*entity.Summary, error) {
+	const op = "storage.postgres.GetActorAgeStats"
+
+	summary, err := cache.Get(s.statsCache, "actor_ages", func() (*entity.Summary, error) {
+		var summary entity.Summary
+		err := s.db.QueryRow(
+			`SELECT ` + summaryColumns + `
+					FROM (SELECT extract(YEAR FROM age(m.release_date, a.birth_date))::int AS v
+						FROM movie_actors ma
+						JOIN movies m ON m.id = ma.movie_id
+						JOIN actors a ON a.id = ma.actor_id
+						WHERE m.release_date >= a.birth_date AND m.deleted_at IS NULL AND a.deleted_at IS NULL) ages`).
+			Scan(summaryDest(&summary)...)
+		return &summary, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return summary, nil
+}
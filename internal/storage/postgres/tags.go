@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"strings"
+)
+
+// likeEscaper escapes LIKE pattern wildcards, so that user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetTags returns tags attached to at least one movie or actor that is not deleted, starting with given prefix.
+// Most used tags come first.
+func (s *Storage) GetTags(prefix string, limit, offset int) ([]entity.Tag, error) {
+	const op = "storage.postgres.GetTags"
+
+	stmt, err := s.db.Prepare(
+		`SELECT name, movies, actors FROM (
+					SELECT tg.name,
+						(SELECT count(*) FROM movie_tags mtg WHERE mtg.tag_id = tg.id AND ` + liveMovie("mtg.movie_id") + `) AS movies,
+						(SELECT count(*) FROM actor_tags atg WHERE atg.tag_id = tg.id AND ` + liveActor("atg.actor_id") + `) AS actors
+					FROM tags tg
+					WHERE tg.name LIKE $1) t
+				WHERE movies + actors > 0
+				ORDER BY movies + actors DESC, name
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(likeEscaper.Replace(prefix)+"%", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []entity.Tag
+	for rows.Next() {
+		var tag entity.Tag
+		if err := rows.Scan(&tag.Name, &tag.Movies, &tag.Actors); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// AddMovieTag attaches tag to movie, creating the tag if it does not exist yet.
+func (s *Storage) AddMovieTag(movieID int, tag string) error {
+	const op = "storage.postgres.AddMovieTag"
+
+	if err := s.addTag("movies", "movie_tags", "movie_id", movieID, tag); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return storage.ErrMovieNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveMovieTag(movieID int, tag string) error {
+	const op = "storage.postgres.RemoveMovieTag"
+
+	if err := s.removeTag("movies", "movie_tags", "movie_id", movieID, tag); err != nil {
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddActorTag attaches tag to actor, creating the tag if it does not exist yet.
+func (s *Storage) AddActorTag(actorID int, tag string) error {
+	const op = "storage.postgres.AddActorTag"
+
+	if err := s.addTag("actors", "actor_tags", "actor_id", actorID, tag); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return storage.ErrActorNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveActorTag(actorID int, tag string) error {
+	const op = "storage.postgres.RemoveActorTag"
+
+	if err := s.removeTag("actors", "actor_tags", "actor_id", actorID, tag); err != nil {
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// addTag links tag to row of parent table through link table by column, tag is created in the same statement,
+// so it is not left behind when the row does not exist. Parent row is touched when the tag is new to it.
+func (s *Storage) addTag(parent, table, column string, id int, tag string) error {
+	stmt, err := s.db.Prepare(fmt.Sprintf(
+		`WITH tag AS (
+					INSERT INTO tags (name) VALUES ($2)
+					ON CONFLICT (name) DO UPDATE SET name = excluded.name
+					RETURNING id),
+				added AS (
+					INSERT INTO %[1]s (%[2]s, tag_id) SELECT $1::INT, id FROM tag
+					ON CONFLICT DO NOTHING
+					RETURNING %[2]s)
+				UPDATE %[3]s SET updated_at = now() WHERE id IN (SELECT %[2]s FROM added)`, table, column, parent))
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id, tag)
+	return err
+}
+
+func (s *Storage) removeTag(parent, table, column string, id int, tag string) error {
+	stmt, err := s.db.Prepare(touch(parent, fmt.Sprintf(
+		`DELETE FROM %[1]s tt USING tags tg
+				WHERE tt.tag_id = tg.id AND tt.%[2]s = $1 AND tg.name = $2
+				RETURNING tt.%[2]s AS id`, table, column)))
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.Exec(id, tag)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return storage.ErrTagNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+)
+
+// GetMovieTranslations returns all translations of movie ordered by language.
+func (s *Storage) GetMovieTranslations(movieID int) ([]entity.MovieTranslation, error) {
+	const op = "storage.postgres.GetMovieTranslations"
+
+	stmt, err := s.db.Prepare(
+		`SELECT lang, title, COALESCE(description, '') FROM movie_translations
+				WHERE movie_id = $1 AND ` + liveMovie("movie_id") + ` ORDER BY lang`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var translations []entity.MovieTranslation
+	for rows.Next() {
+		var translation entity.MovieTranslation
+		err = rows.Scan(&translation.Lang, &translation.Title, &translation.Description)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, nil
+}
+
+// SetMovieTranslation creates or replaces movie translation to the language of given translation.
+func (s *Storage) SetMovieTranslation(movieID int, translation *entity.MovieTranslation) error {
+	const op = "storage.postgres.SetMovieTranslation"
+
+	stmt, err := s.db.Prepare(touch("movies",
+		`INSERT INTO movie_translations (movie_id, lang, title, description) VALUES ($1, $2, $3, NULLIF($4, ''))
+				ON CONFLICT (movie_id, lang) DO UPDATE SET title = excluded.title, description = excluded.description
+				RETURNING movie_id AS id`))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.Exec(movieID, translation.Lang, translation.Title, translation.Description)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return storage.ErrMovieNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveMovieTranslation(movieID int, lang string) error {
+	const op = "storage.postgres.RemoveMovieTranslation"
+
+	stmt, err := s.db.Prepare(touch("movies", "DELETE FROM movie_translations WHERE movie_id = $1 AND lang = $2 RETURNING movie_id AS id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(movieID, lang)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+// GetActorTranslations returns all translations of actor name ordered by language.
+func (s *Storage) GetActorTranslations(actorID int) ([]entity.ActorTranslation, error) {
+	const op = "storage.postgres.GetActorTranslations"
+
+	stmt, err := s.db.Prepare("SELECT lang, name FROM actor_translations WHERE actor_id = $1 AND " + liveActor("actor_id") + " ORDER BY lang")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var translations []entity.ActorTranslation
+	for rows.Next() {
+		var translation entity.ActorTranslation
+		err = rows.Scan(&translation.Lang, &translation.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, nil
+}
+
+// SetActorTranslation creates or replaces actor name translation to the language of given translation.
+func (s *Storage) SetActorTranslation(actorID int, translation *entity.ActorTranslation) error {
+	const op = "storage.postgres.SetActorTranslation"
+
+	stmt, err := s.db.Prepare(touch("actors",
+		`INSERT INTO actor_translations (actor_id, lang, name) VALUES ($1, $2, $3)
+				ON CONFLICT (actor_id, lang) DO UPDATE SET name = excluded.name
+				RETURNING actor_id AS id`))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.Exec(actorID, translation.Lang, translation.Name)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return storage.ErrActorNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveActorTranslation(actorID int, lang string) error {
+	const op = "storage.postgres.RemoveActorTranslation"
+
+	stmt, err := s.db.Prepare(touch("actors", "DELETE FROM actor_translations WHERE actor_id = $1 AND lang = $2 RETURNING actor_id AS id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(actorID, lang)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrTranslationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"time"
+)
+
+// watchlistSortColumns maps sort fields accepted by /api/me endpoints to columns.
+// Both watchlist and history queries alias the personal date column as `d`.
+var watchlistSortColumns = map[string]string{
+	"date":         "d",
+	"title":        "m.title",
+	"release_date": "m.release_date",
+	"rating":       "m.rating",
+}
+
+func orderClause(columns map[string]string, orderBy string, asc bool) string {
+	column, ok := columns[orderBy]
+	if !ok {
+		column = columns["date"]
+	}
+
+	orderDir := "DESC"
+	if asc {
+		orderDir = "ASC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", column, orderDir)
+}
+
+func (s *Storage) GetWatchlist(username string, limit, offset int, orderBy string, asc bool) ([]entity.WatchlistEntry, error) {
+	const op = "storage.postgres.GetWatchlist"
+
+	stmt, err := s.db.Prepare(
+		`SELECT ` + movieColumns + `, w.added_at AS d
+				FROM watchlist w
+				JOIN movies m ON m.id = w.movie_id
+				WHERE w.username = $1 AND m.deleted_at IS NULL ` +
+			orderClause(watchlistSortColumns, orderBy, asc) + `
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []entity.WatchlistEntry
+	for rows.Next() {
+		var entry entity.WatchlistEntry
+		err = scanMovie(rows, &entry.Movie, &entry.AddedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		inWatchlist := true
+		entry.Movie.InWatchlist = &inWatchlist
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// AddToWatchlist adds live movie to watchlist, adding movie that is already there succeeds
+func (s *Storage) AddToWatchlist(username string, movieID int) error {
+	const op = "storage.postgres.AddToWatchlist"
+
+	stmt, err := s.db.Prepare(
+		`WITH movie AS (SELECT id FROM movies WHERE id = $2 AND deleted_at IS NULL),
+				added AS (INSERT INTO watchlist (username, movie_id) SELECT $1, id FROM movie ON CONFLICT DO NOTHING RETURNING movie_id),
+				touched AS (UPDATE movies SET updated_at = now() WHERE id IN (SELECT movie_id FROM added))
+				SELECT EXISTS (SELECT 1 FROM movie)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var exists bool
+	err = stmt.QueryRow(username, movieID).Scan(&exists)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return storage.ErrMovieNotFound
+		}
+		return fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+	if !exists {
+		return storage.ErrMovieNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveFromWatchlist(username string, movieID int) error {
+	const op = "storage.postgres.RemoveFromWatchlist"
+
+	stmt, err := s.db.Prepare(touch("movies", "DELETE FROM watchlist WHERE username = $1 AND movie_id = $2 RETURNING movie_id AS id"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(username, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrWatchlistEntryNotFound
+	}
+
+	return nil
+}
+
+// FlagWatchlisted sets InWatchlist flag of given movies for given user.
+func (s *Storage) FlagWatchlisted(username string, movies []entity.Movie) error {
+	const op = "storage.postgres.FlagWatchlisted"
+
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(movies))
+	for i, movie := range movies {
+		ids[i] = int64(movie.ID)
+	}
+
+	stmt, err := s.db.Prepare("SELECT movie_id FROM watchlist WHERE username = $1 AND movie_id = ANY($2)")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(username, pq.Int64Array(ids))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	watchlisted := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		watchlisted[id] = true
+	}
+
+	for i := range movies {
+		inWatchlist := watchlisted[movies[i].ID]
+		movies[i].InWatchlist = &inWatchlist
+	}
+
+	return nil
+}
+
+func (s *Storage) GetHistory(username string, limit, offset int, orderBy string, asc bool) ([]entity.HistoryEntry, error) {
+	const op = "storage.postgres.GetHistory"
+
+	stmt, err := s.db.Prepare(
+		`SELECT ` + movieColumns + `, h.id, h.watched_on AS d
+				FROM watch_history h
+				JOIN movies m ON m.id = h.movie_id
+				WHERE h.username = $1 AND m.deleted_at IS NULL ` +
+			orderClause(watchlistSortColumns, orderBy, asc) + `, h.id DESC
+				LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.Query(username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []entity.HistoryEntry
+	for rows.Next() {
+		var entry entity.HistoryEntry
+		err = scanMovie(rows, &entry.Movie, &entry.ID, &entry.WatchedOn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// AddToHistory records viewing of live movie and returns id of the entry
+func (s *Storage) AddToHistory(username string, entry *entity.NewHistoryEntry) (int, error) {
+	const op = "storage.postgres.AddToHistory"
+
+	watchedOn := entry.WatchedOn
+	if watchedOn.IsZero() {
+		watchedOn = time.Now()
+	}
+
+	stmt, err := s.db.Prepare(
+		`INSERT INTO watch_history (username, movie_id, watched_on)
+				SELECT $1, id, $3 FROM movies WHERE id = $2 AND deleted_at IS NULL
+				RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = stmt.QueryRow(username, entry.MovieID, watchedOn).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return 0, storage.ErrMovieNotFound
+		}
+		return 0, fmt.Errorf("%s: %w", op, constraintError(err))
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RemoveFromHistory(username string, id int) error {
+	const op = "storage.postgres.RemoveFromHistory"
+
+	stmt, err := s.db.Prepare("DELETE FROM watch_history WHERE username = $1 AND id = $2")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(username, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrHistoryEntryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/lib/pq"
+	"github.com/rmntim/movielab/internal/entity"
+	"github.com/rmntim/movielab/internal/storage"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAddToWatchlist(t *testing.T) {
+	tests := []struct {
+		name  string
+		query fakeQuery
+		err   error
+	}{
+		{
+			name:  "Success",
+			query: fakeQuery{match: "INSERT INTO watchlist", args: []driver.Value{"user", int64(1)}, rows: [][]driver.Value{{true}}},
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "INSERT INTO watchlist", rows: [][]driver.Value{{false}}},
+			err:   storage.ErrMovieNotFound,
+		},
+		{
+			name:  "Movie purged concurrently",
+			query: fakeQuery{match: "INSERT INTO watchlist", err: &pq.Error{Code: foreignKeyViolation}},
+			err:   storage.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			err := s.AddToWatchlist("user", 1)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAddToHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		query fakeQuery
+		id    int
+		err   error
+	}{
+		{
+			name:  "Success",
+			query: fakeQuery{match: "INSERT INTO watch_history", rows: [][]driver.Value{{int64(7)}}},
+			id:    7,
+		},
+		{
+			name:  "Missing or deleted movie",
+			query: fakeQuery{match: "INSERT INTO watch_history"},
+			err:   storage.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newFakeStorage(t, tt.query)
+
+			id, err := s.AddToHistory("user", &entity.NewHistoryEntry{MovieID: 1})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.id, id)
+		})
+	}
+}
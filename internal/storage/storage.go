@@ -5,7 +5,68 @@ import (
 )
 
 var (
-	ErrMovieNotFound = errors.New("movie not found")
+	ErrMovieNotFound    = errors.New("movie not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrVersionConflict  = errors.New("version conflict")
 
 	ErrActorNotFound = errors.New("actor not found")
+	ErrPathNotFound  = errors.New("path not found")
+
+	ErrPersonNotFound = errors.New("person not found")
+	ErrCreditNotFound = errors.New("credit not found")
+	ErrCreditExists   = errors.New("credit already exists")
+
+	ErrReviewNotFound = errors.New("review not found")
+	ErrReviewExists   = errors.New("review already exists")
+
+	ErrWatchlistEntryNotFound = errors.New("watchlist entry not found")
+	ErrHistoryEntryNotFound   = errors.New("history entry not found")
+
+	ErrCollectionNotFound      = errors.New("collection not found")
+	ErrCollectionEntryNotFound = errors.New("collection entry not found")
+	ErrCollectionEntryExists   = errors.New("movie is already in collection")
+
+	ErrTranslationNotFound = errors.New("translation not found")
+
+	ErrTagNotFound = errors.New("tag not found")
+
+	ErrAwardNotFound         = errors.New("award not found")
+	ErrAwardExists           = errors.New("award already exists")
+	ErrAwardCategoryNotFound = errors.New("award category not found")
+	ErrAwardCategoryExists   = errors.New("award category already exists")
+	ErrCeremonyExists        = errors.New("ceremony already exists")
+	ErrNominationNotFound    = errors.New("nomination not found")
+
+	// Errors of violated database constraints, they come wrapped into ConstraintError
+	ErrReferenceNotFound  = errors.New("referenced record not found")
+	ErrDuplicate          = errors.New("duplicate record")
+	ErrValueRequired      = errors.New("value required")
+	ErrConstraintViolated = errors.New("constraint violated")
 )
+
+// ConstraintError tells which field of stored entity violated database constraint
+type ConstraintError struct {
+	// Err is one of constraint errors above
+	Err error
+	// Constraint is name of violated constraint, empty for NOT NULL ones
+	Constraint string
+	// Field is JSON path of the offending field, e.g. `actor_ids` or `budget.amount`, empty if unknown
+	Field string
+	// Value is the offending value, e.g. id of missing record, when database reports it
+	Value string
+}
+
+func (e *ConstraintError) Error() string {
+	msg := e.Err.Error()
+	if e.Field != "" {
+		msg += " in field " + e.Field
+	}
+	if e.Value != "" {
+		msg += ": " + e.Value
+	}
+	return msg
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,27 @@
-DROP TYPE sex;
 DROP TYPE role;
 DROP TABLE users;
 DROP TABLE actors;
+DROP TYPE sex;
 DROP TABLE movies;
-DROP TABLE movie_actors;
\ No newline at end of file
+DROP TABLE movie_actors;
+DROP TABLE movie_crew;
+DROP TYPE crew_role;
+DROP TABLE reviews;
+DROP TABLE watchlist;
+DROP TABLE watch_history;
+DROP TABLE collection_movies;
+DROP TABLE collections;
+DROP TABLE movie_translations;
+DROP TABLE actor_translations;
+DROP TABLE actor_redirects;
+DROP TABLE movie_tags;
+DROP TABLE actor_tags;
+DROP TABLE tags;
+DROP TABLE nominations;
+DROP TABLE award_ceremonies;
+DROP TABLE award_categories;
+DROP TABLE awards;
+DROP TABLE audit_log;
+DROP TABLE movie_cast_revisions;
+DROP TABLE movie_revisions;
+DROP TABLE actor_revisions;
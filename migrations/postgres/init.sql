@@ -33,4 +33,295 @@ CREATE TABLE IF NOT EXISTS movie_actors
     movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
     actor_id INT NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
     PRIMARY KEY (movie_id, actor_id)
-);
\ No newline at end of file
+);
+
+CREATE TYPE crew_role AS ENUM ('director', 'writer', 'producer', 'cinematographer', 'composer');
+
+-- Crew credits reference actors table, so a single person can both act and work in crew
+CREATE TABLE IF NOT EXISTS movie_crew
+(
+    movie_id  INT       NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    person_id INT       NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    role      crew_role NOT NULL,
+    PRIMARY KEY (movie_id, person_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS movie_crew_person_idx ON movie_crew (person_id);
+
+CREATE TABLE IF NOT EXISTS reviews
+(
+    id         SERIAL PRIMARY KEY,
+    movie_id   INT          NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    username   VARCHAR(255) NOT NULL REFERENCES users (username) ON DELETE CASCADE,
+    rating     INT          NOT NULL
+        CONSTRAINT review_rating_check CHECK (rating >= 0 AND rating <= 10),
+    text       VARCHAR(5000),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (movie_id, username)
+);
+
+CREATE TABLE IF NOT EXISTS watchlist
+(
+    username VARCHAR(255) NOT NULL REFERENCES users (username) ON DELETE CASCADE,
+    movie_id INT          NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    added_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (username, movie_id)
+);
+
+CREATE TABLE IF NOT EXISTS watch_history
+(
+    id         SERIAL PRIMARY KEY,
+    username   VARCHAR(255) NOT NULL REFERENCES users (username) ON DELETE CASCADE,
+    movie_id   INT          NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    watched_on DATE         NOT NULL DEFAULT CURRENT_DATE
+);
+
+CREATE INDEX IF NOT EXISTS watch_history_username_idx ON watch_history (username, watched_on);
+
+CREATE TABLE IF NOT EXISTS collections
+(
+    id          SERIAL PRIMARY KEY,
+    title       VARCHAR(150) NOT NULL,
+    description VARCHAR(1000),
+    owner       VARCHAR(255) NOT NULL REFERENCES users (username) ON DELETE CASCADE,
+    public      BOOLEAN      NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS collection_movies
+(
+    collection_id INT NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    movie_id      INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    position      INT NOT NULL,
+    PRIMARY KEY (collection_id, movie_id)
+);
+
+-- Images hold URLs of original picture and its thumbnails, see entity.Image
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS poster JSONB;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS headshot JSONB;
+
+-- Translations are keyed by lowercase BCP 47 language tag, e.g. `pt-br`
+CREATE TABLE IF NOT EXISTS movie_translations
+(
+    movie_id    INT          NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    lang        VARCHAR(35)  NOT NULL,
+    title       VARCHAR(150) NOT NULL,
+    description VARCHAR(1000),
+    PRIMARY KEY (movie_id, lang)
+);
+
+CREATE TABLE IF NOT EXISTS actor_translations
+(
+    actor_id INT          NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    lang     VARCHAR(35)  NOT NULL,
+    name     VARCHAR(255) NOT NULL,
+    PRIMARY KEY (actor_id, lang)
+);
+
+-- Countries and certification keys are ISO 3166-1 alpha-2 codes, original language is ISO 639-1 code,
+-- money amounts are whole units of ISO 4217 currency stored next to them
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS runtime             INT
+        CONSTRAINT runtime_check CHECK (runtime >= 0),
+    ADD COLUMN IF NOT EXISTS countries           VARCHAR(2)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS original_language   VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS certifications      JSONB        NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS budget              BIGINT
+        CONSTRAINT budget_check CHECK (budget >= 0),
+    ADD COLUMN IF NOT EXISTS budget_currency     CHAR(3),
+    ADD COLUMN IF NOT EXISTS box_office          BIGINT
+        CONSTRAINT box_office_check CHECK (box_office >= 0),
+    ADD COLUMN IF NOT EXISTS box_office_currency CHAR(3);
+
+CREATE INDEX IF NOT EXISTS movies_countries_idx ON movies USING GIN (countries);
+CREATE INDEX IF NOT EXISTS movies_certifications_idx ON movies USING GIN (certifications);
+
+-- Gender is free-form addition to binary sex, existing actors start with their sex as gender
+DO
+$$
+    BEGIN
+        IF NOT EXISTS (SELECT 1
+                       FROM information_schema.columns
+                       WHERE table_name = 'actors'
+                         AND column_name = 'gender') THEN
+            ALTER TABLE actors
+                ADD COLUMN gender VARCHAR(50);
+            UPDATE actors SET gender = sex::TEXT;
+        END IF;
+    END
+$$;
+
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS death_date TIMESTAMPTZ
+        CONSTRAINT death_date_check CHECK (death_date > birth_date),
+    ADD COLUMN IF NOT EXISTS birthplace VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS biography  TEXT,
+    ADD COLUMN IF NOT EXISTS aliases    VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+-- Primary key of movie_actors only covers lookups by movie, co-star traversal also goes from actor to movies
+CREATE INDEX IF NOT EXISTS movie_actors_actor_id_idx ON movie_actors (actor_id, movie_id);
+
+-- Trigram similarity of names is used to report possible duplicate actors
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS actors_birth_date_idx ON actors (birth_date);
+CREATE INDEX IF NOT EXISTS actors_lower_name_idx ON actors (lower(name));
+
+-- Redirects keep ids of merged duplicate actors resolvable
+CREATE TABLE IF NOT EXISTS actor_redirects
+(
+    old_id    INT PRIMARY KEY,
+    actor_id  INT         NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Tag names are normalized by entity.NormalizeTag, pattern ops index serves prefix autocomplete
+CREATE TABLE IF NOT EXISTS tags
+(
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tags_name_pattern_idx ON tags (name text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS movie_tags
+(
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    tag_id   INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (movie_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_tags_tag_id_idx ON movie_tags (tag_id, movie_id);
+
+CREATE TABLE IF NOT EXISTS actor_tags
+(
+    actor_id INT NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    tag_id   INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (actor_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS actor_tags_tag_id_idx ON actor_tags (tag_id, actor_id);
+
+-- Awards are recurring events like Academy Awards, each held in yearly ceremonies and giving awards in categories
+CREATE TABLE IF NOT EXISTS awards
+(
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS award_categories
+(
+    id       SERIAL PRIMARY KEY,
+    award_id INT          NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    name     VARCHAR(255) NOT NULL,
+    UNIQUE (award_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS award_ceremonies
+(
+    id       SERIAL PRIMARY KEY,
+    award_id INT NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    year     INT NOT NULL,
+    held_on  DATE,
+    UNIQUE (award_id, year)
+);
+
+-- Nomination of a movie, an actor, or an actor for a movie; won marks the winners
+CREATE TABLE IF NOT EXISTS nominations
+(
+    id          SERIAL PRIMARY KEY,
+    ceremony_id INT     NOT NULL REFERENCES award_ceremonies (id) ON DELETE CASCADE,
+    category_id INT     NOT NULL REFERENCES award_categories (id) ON DELETE CASCADE,
+    movie_id    INT REFERENCES movies (id) ON DELETE CASCADE,
+    actor_id    INT REFERENCES actors (id) ON DELETE CASCADE,
+    won         BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT nominee_check CHECK (movie_id IS NOT NULL OR actor_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS nominations_ceremony_id_idx ON nominations (ceremony_id);
+CREATE INDEX IF NOT EXISTS nominations_category_id_idx ON nominations (category_id, won);
+CREATE INDEX IF NOT EXISTS nominations_movie_id_idx ON nominations (movie_id, won);
+CREATE INDEX IF NOT EXISTS nominations_actor_id_idx ON nominations (actor_id, won);
+
+-- Deleted movies and actors are kept with their links until purged after retention period
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS movies_deleted_at_idx ON movies (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS actors_deleted_at_idx ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Audit log of changes to movies and actors, changes hold before and after values of changed fields.
+-- Username is not a foreign key, so the record outlives removed users.
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    username    VARCHAR(255) NOT NULL,
+    action      VARCHAR(20)  NOT NULL,
+    entity_type VARCHAR(20)  NOT NULL,
+    entity_id   INT          NOT NULL,
+    changes     JSONB        NOT NULL DEFAULT '{}',
+    request_id  VARCHAR(128),
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_username_idx ON audit_log (username, created_at);
+
+-- Revisions are numbered per entity and hold row data as JSON, so they survive later column changes.
+-- Cast of each movie revision is kept next to it, with links to deleted actors included.
+CREATE TABLE IF NOT EXISTS movie_revisions
+(
+    movie_id   INT          NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    revision   INT          NOT NULL,
+    data       JSONB        NOT NULL,
+    username   VARCHAR(255),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (movie_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS movie_cast_revisions
+(
+    movie_id INT NOT NULL,
+    revision INT NOT NULL,
+    actor_id INT NOT NULL,
+    PRIMARY KEY (movie_id, revision, actor_id),
+    FOREIGN KEY (movie_id, revision) REFERENCES movie_revisions (movie_id, revision) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS actor_revisions
+(
+    actor_id   INT          NOT NULL REFERENCES actors (id) ON DELETE CASCADE,
+    revision   INT          NOT NULL,
+    data       JSONB        NOT NULL,
+    username   VARCHAR(255),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (actor_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS movie_revisions_created_at_idx ON movie_revisions (movie_id, created_at);
+
+-- Existing rows get their current state as the first revision, without author
+WITH backfilled AS (
+    INSERT INTO movie_revisions (movie_id, revision, data)
+        SELECT m.id, 1, to_jsonb(m)
+        FROM movies m
+        WHERE NOT EXISTS (SELECT 1 FROM movie_revisions r WHERE r.movie_id = m.id)
+        RETURNING movie_id)
+INSERT
+INTO movie_cast_revisions (movie_id, revision, actor_id)
+SELECT ma.movie_id, 1, ma.actor_id
+FROM movie_actors ma
+         JOIN backfilled b ON b.movie_id = ma.movie_id;
+
+INSERT INTO actor_revisions (actor_id, revision, data)
+SELECT a.id, 1, to_jsonb(a)
+FROM actors a
+WHERE NOT EXISTS (SELECT 1 FROM actor_revisions r WHERE r.actor_id = a.id);
+
+-- Version is incremented by every change of the row, it is sent as ETag for optimistic concurrency control
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+-- Update time is set together with version, it is sent as Last-Modified for conditional requests
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();